@@ -10,7 +10,6 @@ import (
 
 	"log/slog"
 
-	"github.com/go-gl/gl/v4.6-core/gl"
 	"github.com/soypat/glgl/v4.6-core/glgl"
 )
 
@@ -46,11 +45,13 @@ func main() {
 		slog.Error("parsing", "err", err.Error())
 		return
 	}
-	prog, err := glgl.CompileProgram(ss)
+	cp, err := glgl.NewComputePipeline(ss)
 	if err != nil {
-		slog.Error("creating program", "err", err.Error())
+		slog.Error("creating compute pipeline", "err", err.Error())
 		return
 	}
+	defer cp.Delete()
+	prog := cp.Program()
 	prog.Bind()
 	adderLoc, err := prog.UniformLocation("u_adder\x00")
 	if err != nil {
@@ -63,38 +64,31 @@ func main() {
 		return
 	}
 
-	const unit = 0
-	cfg := glgl.TextureImgConfig{
-		Type:           glgl.Texture2D,
-		Width:          width,
-		Height:         height,
-		Access:         glgl.ReadOrWrite,
-		Format:         gl.RED,
-		MinFilter:      gl.NEAREST,
-		MagFilter:      gl.NEAREST,
-		Xtype:          gl.FLOAT,
-		InternalFormat: gl.R32F,
-		ImageUnit:      unit,
+	// Texture format and image unit are taken from the out_tex declaration in the shader.
+	err = cp.AddImage("out_tex", width, height)
+	if err != nil {
+		slog.Error("creating texture", "err", err.Error())
+		return
 	}
 	// DST starts with ones, and we add the uniform variable too all values.
 	dst := make([]float32, width*height)
 	for i := range dst {
 		dst[i]++
 	}
-	tex, err := glgl.NewTextureFromImage(cfg, dst)
+	err = glgl.SetComputeInput(cp, "out_tex", dst)
 	if err != nil {
-		slog.Error("creating texture", "err", err.Error())
+		slog.Error("setting texture data", "err", err.Error())
 		return
 	}
 
 	// Dispatch and wait for compute to finish.
-	err = prog.RunCompute(width, height, 1)
+	err = cp.Run(width, height, 1)
 	if err != nil {
 		slog.Error("running compute shader", "err", err.Error())
 		return
 	}
 
-	err = glgl.GetImage(dst, tex, cfg)
+	err = glgl.GetComputeOutput(cp, "out_tex", dst)
 	if err != nil {
 		slog.Error("acquiring results from GPU", "err", err.Error())
 		return
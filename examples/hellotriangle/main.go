@@ -76,7 +76,7 @@ func main() {
 	}
 	for !window.ShouldClose() {
 		gl.Clear(gl.COLOR_BUFFER_BIT)
-		// NOTE: If nothing is visible maybe add a gl.BindVertexArray(vao) call in here and file a bug!
+		// NOTE: If nothing is visible try calling vao.Rebind() in here and inspect vao.Layouts(), then file a bug!
 		gl.DrawArrays(gl.TRIANGLES, 0, 3)
 		// Maintenance
 		window.SwapBuffers()
@@ -105,6 +105,13 @@ func main() {
 		return
 	}
 
+	// Image formats of bound textures must match the shader's format qualifiers.
+	err = prog.ValidateImageFormats(ss)
+	if err != nil {
+		slog.Error("validating image formats", "err", err.Error())
+		return
+	}
+
 	// Dispatch and wait for compute to finish.
 	err = prog.RunCompute(len(inputArray), 1, 1)
 	if err != nil {
@@ -8,14 +8,15 @@ package main
 import (
 	"bytes"
 	"fmt"
-	"io"
 	"log"
 	"math"
 	"runtime"
 	"strconv"
 
 	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/soypat/glgl/math/ms3"
 	"github.com/soypat/glgl/v4.6-core/glgl"
+	"github.com/soypat/glgl/v4.6-core/glgl/sdf"
 )
 
 func init() {
@@ -23,11 +24,11 @@ func init() {
 	runtime.LockOSThread()
 }
 
-func makeScene() SDFShaderer {
+func makeScene() sdf.Shaderer {
 	// Make SDF shader program.
 	s1, _ := NewSphere(0.5)
 	s2, _ := NewSphere(1)
-	s1 = Translate(s1, Vec{X: 2})
+	s1 = Translate(s1, ms3.Vec{X: 2})
 	obj := Union(s1, s2)
 	return obj
 }
@@ -46,15 +47,14 @@ func main() {
 	defer terminate()
 
 	var source bytes.Buffer
-	_, err = writeProgram(&source, makeScene())
+	err = sdf.WriteProgram(&source, makeScene())
 	if err != nil {
 		panic(err)
 	}
 
-	// return
 	ss, err := glgl.ParseCombined(&source)
 	if err != nil {
-		log.Println("parsing:", err)
+		log.Println("parsing program:", err)
 		return
 	}
 	prog, err := glgl.CompileProgram(ss)
@@ -117,6 +117,13 @@ func main() {
 		return
 	}
 
+	// Image formats of bound textures must match the shader's format qualifiers.
+	err = prog.ValidateImageFormats(ss)
+	if err != nil {
+		log.Println("validating image formats:", err)
+		return
+	}
+
 	// Dispatch and wait for compute to finish.
 	err = prog.RunCompute(len(inputArray), 1, 1)
 	if err != nil {
@@ -136,13 +143,9 @@ func main() {
 	// fmt.Println(source.String()) // Print generated shader source code.
 }
 
-type Vec struct {
-	X, Y, Z float32
-}
-
 type SDF interface {
-	Evaluate(positions []Vec, distances []float32) (int, error)
-	Bounds() (min, max Vec)
+	Evaluate(positions []ms3.Vec, distances []float32) (int, error)
+	Bounds() (min, max ms3.Vec)
 }
 
 type Sphere struct {
@@ -152,28 +155,36 @@ type Sphere struct {
 const fltPrec = 8
 const fltFmtByte = 'g'
 
-type SDFShader struct {
-	Name []byte
-	Body []byte
+// appendNameFloat appends v to a GLSL identifier. Periods and minus signs are not
+// valid in identifiers so they are replaced with 'p' and 'n'. v is formatted without
+// exponent since exponents of small values contain a minus sign, i.e: 1e-05.
+func appendNameFloat(name []byte, v float32) []byte {
+	start := len(name)
+	name = strconv.AppendFloat(name, float64(v), 'f', -1, 32)
+	for i := start; i < len(name); i++ {
+		switch name[i] {
+		case '.':
+			name[i] = 'p'
+		case '-':
+			name[i] = 'n'
+		}
+	}
+	return name
 }
 
-func (s *Sphere) ForEachChild(flags int, fn func(flags int, s SDFShaderer) error) error { return nil }
+func (s *Sphere) ForEachChild(flags int, fn func(flags int, s sdf.Shaderer) error) error { return nil }
 
-func (s *Sphere) AppendShader(glsl *SDFShader) error {
+func (s *Sphere) AppendShader(glsl *sdf.Shader) error {
 	r := float64(s.R)
 	glsl.Name = append(glsl.Name, "sphere"...)
-	glsl.Name = strconv.AppendFloat(glsl.Name, r, fltFmtByte, fltPrec, 32)
-	if idx := bytes.IndexByte(glsl.Name, '.'); idx >= 0 {
-		// Identifiers cannot have period in name.
-		glsl.Name[idx] = 'p'
-	}
+	glsl.Name = appendNameFloat(glsl.Name, s.R)
 	glsl.Body = append(glsl.Body, "return length(p)-"...)
 	glsl.Body = strconv.AppendFloat(glsl.Body, r, fltFmtByte, fltPrec, 32)
 	glsl.Body = append(glsl.Body, ';')
 	return nil
 }
 
-func (s *Sphere) Evaluate(positions []Vec, distances []float32) (int, error) {
+func (s *Sphere) Evaluate(positions []ms3.Vec, distances []float32) (int, error) {
 	for i, pos := range positions {
 		r := norm(pos)
 		distances[i] = r - s.R
@@ -181,25 +192,19 @@ func (s *Sphere) Evaluate(positions []Vec, distances []float32) (int, error) {
 	return 0, nil
 }
 
-func (s *Sphere) Bounds() (min, max Vec) {
-	min = Vec{X: s.R, Y: s.R, Z: s.R}
-	max = Vec{X: s.R, Y: s.R, Z: s.R}
+func (s *Sphere) Bounds() (min, max ms3.Vec) {
+	min = ms3.Vec{X: -s.R, Y: -s.R, Z: -s.R}
+	max = ms3.Vec{X: s.R, Y: s.R, Z: s.R}
 	return min, max
 }
 
-type SDFShaderer interface {
-	Bounds() (min, max Vec)
-	AppendShader(glsl *SDFShader) error
-	ForEachChild(flags int, fn func(flags int, s SDFShaderer) error) error
-}
-
 type BinaryOpShader struct {
-	s1, s2  SDFShaderer
+	s1, s2  sdf.Shaderer
 	opname  string
 	bodyFmt string
 }
 
-func Union(s1, s2 SDFShaderer) SDFShaderer {
+func Union(s1, s2 sdf.Shaderer) sdf.Shaderer {
 	if s1 == nil || s2 == nil {
 		panic("nil object")
 	}
@@ -210,18 +215,22 @@ func Union(s1, s2 SDFShaderer) SDFShaderer {
 }
 
 type UnionShader struct {
-	s1, s2 SDFShaderer
+	s1, s2 sdf.Shaderer
+	bb     boundsCache
 }
 
-func (s *UnionShader) Bounds() (vmin, vmax Vec) {
+func (s *UnionShader) Bounds() (vmin, vmax ms3.Vec) {
+	if s.bb.ok {
+		return s.bb.min, s.bb.max
+	}
 	min1, max1 := s.s1.Bounds()
 	min2, max2 := s.s2.Bounds()
-	vmin = Vec{X: minf(min1.X, min2.X), Y: minf(min1.Y, min2.Y), Z: minf(min1.Z, min2.Z)}
-	vmax = Vec{X: maxf(max1.X, max2.X), Y: maxf(max1.Y, max2.Y), Z: maxf(max1.Z, max2.Z)}
-	return vmin, vmax
+	vmin = ms3.Vec{X: minf(min1.X, min2.X), Y: minf(min1.Y, min2.Y), Z: minf(min1.Z, min2.Z)}
+	vmax = ms3.Vec{X: maxf(max1.X, max2.X), Y: maxf(max1.Y, max2.Y), Z: maxf(max1.Z, max2.Z)}
+	return s.bb.set(vmin, vmax)
 }
 
-func (s *UnionShader) ForEachChild(flags int, fn func(flags int, s SDFShaderer) error) error {
+func (s *UnionShader) ForEachChild(flags int, fn func(flags int, s sdf.Shaderer) error) error {
 	err := fn(flags, s.s1)
 	if err != nil {
 		return err
@@ -229,20 +238,18 @@ func (s *UnionShader) ForEachChild(flags int, fn func(flags int, s SDFShaderer)
 	return fn(flags, s.s2)
 }
 
-func (s *UnionShader) AppendShader(glsl *SDFShader) error {
-	body := glsl.Body
+func (s *UnionShader) AppendShader(glsl *sdf.Shader) error {
 	glsl.Name = append(glsl.Name, "union_"...)
 	id1Start := len(glsl.Name)
-	err := s.s1.AppendShader(glsl)
+	err := glsl.AppendChildName(s.s1)
 	if err != nil {
 		return err
 	}
 	id2Start := len(glsl.Name)
-	err = s.s2.AppendShader(glsl)
+	err = glsl.AppendChildName(s.s2)
 	if err != nil {
 		return err
 	}
-	glsl.Body = glsl.Body[:len(body)] // Remove union element bodies but retain longer
 	glsl.Body = append(glsl.Body, "return min("...)
 	glsl.Body = append(glsl.Body, glsl.Name[id1Start:id2Start]...)
 	glsl.Body = append(glsl.Body, "(p),"...)
@@ -251,11 +258,189 @@ func (s *UnionShader) AppendShader(glsl *SDFShader) error {
 	return nil
 }
 
-func NewSphere(radius float32) (SDFShaderer, error) {
+// SmoothUnion joins s1 and s2 blending the seam with the polynomial smooth-minimum
+// over a distance k, avoiding the crease left by [Union].
+func SmoothUnion(s1, s2 sdf.Shaderer, k float32) sdf.Shaderer {
+	if s1 == nil || s2 == nil {
+		panic("nil object")
+	} else if k <= 0 {
+		panic("smooth union blend radius must be positive")
+	}
+	return &SmoothUnionShader{
+		s1: s1,
+		s2: s2,
+		k:  k,
+	}
+}
+
+type SmoothUnionShader struct {
+	s1, s2 sdf.Shaderer
+	k      float32
+	bb     boundsCache
+}
+
+// Bounds returns the union bounds expanded by the blend radius k, which is conservative.
+func (s *SmoothUnionShader) Bounds() (vmin, vmax ms3.Vec) {
+	if s.bb.ok {
+		return s.bb.min, s.bb.max
+	}
+	min1, max1 := s.s1.Bounds()
+	min2, max2 := s.s2.Bounds()
+	k := s.k
+	vmin = ms3.Vec{X: minf(min1.X, min2.X) - k, Y: minf(min1.Y, min2.Y) - k, Z: minf(min1.Z, min2.Z) - k}
+	vmax = ms3.Vec{X: maxf(max1.X, max2.X) + k, Y: maxf(max1.Y, max2.Y) + k, Z: maxf(max1.Z, max2.Z) + k}
+	return s.bb.set(vmin, vmax)
+}
+
+func (s *SmoothUnionShader) ForEachChild(flags int, fn func(flags int, s sdf.Shaderer) error) error {
+	err := fn(flags, s.s1)
+	if err != nil {
+		return err
+	}
+	return fn(flags, s.s2)
+}
+
+func (s *SmoothUnionShader) AppendShader(glsl *sdf.Shader) error {
+	glsl.Name = append(glsl.Name, "smoothunion"...)
+	glsl.Name = appendNameFloat(glsl.Name, s.k)
+	glsl.Name = append(glsl.Name, '_')
+	id1Start := len(glsl.Name)
+	err := glsl.AppendChildName(s.s1)
+	if err != nil {
+		return err
+	}
+	id2Start := len(glsl.Name)
+	err = glsl.AppendChildName(s.s2)
+	if err != nil {
+		return err
+	}
+	glsl.Body = append(glsl.Body, "float k = "...)
+	glsl.Body = strconv.AppendFloat(glsl.Body, float64(s.k), 'f', fltPrec, 32)
+	glsl.Body = append(glsl.Body, ";\nfloat d1 = "...)
+	glsl.Body = append(glsl.Body, glsl.Name[id1Start:id2Start]...)
+	glsl.Body = append(glsl.Body, "(p);\nfloat d2 = "...)
+	glsl.Body = append(glsl.Body, glsl.Name[id2Start:]...)
+	glsl.Body = append(glsl.Body, "(p);\nfloat h = clamp(0.5+0.5*(d2-d1)/k, 0.0, 1.0);\nreturn mix(d2, d1, h) - k*h*(1.0-h);"...)
+	return nil
+}
+
+func Intersect(s1, s2 sdf.Shaderer) sdf.Shaderer {
+	if s1 == nil || s2 == nil {
+		panic("nil object")
+	}
+	return &IntersectShader{
+		s1: s1,
+		s2: s2,
+	}
+}
+
+type IntersectShader struct {
+	s1, s2 sdf.Shaderer
+	bb     boundsCache
+}
+
+// Bounds returns the overlap of the children's bounds. Children with disjoint bounds
+// have an empty intersection so the bounds collapse to a point at the overlap's center.
+func (s *IntersectShader) Bounds() (vmin, vmax ms3.Vec) {
+	if s.bb.ok {
+		return s.bb.min, s.bb.max
+	}
+	min1, max1 := s.s1.Bounds()
+	min2, max2 := s.s2.Bounds()
+	vmin = ms3.Vec{X: maxf(min1.X, min2.X), Y: maxf(min1.Y, min2.Y), Z: maxf(min1.Z, min2.Z)}
+	vmax = ms3.Vec{X: minf(max1.X, max2.X), Y: minf(max1.Y, max2.Y), Z: minf(max1.Z, max2.Z)}
+	if vmin.X > vmax.X || vmin.Y > vmax.Y || vmin.Z > vmax.Z {
+		center := ms3.Scale(0.5, ms3.Add(vmin, vmax))
+		vmin, vmax = center, center
+	}
+	return s.bb.set(vmin, vmax)
+}
+
+func (s *IntersectShader) ForEachChild(flags int, fn func(flags int, s sdf.Shaderer) error) error {
+	err := fn(flags, s.s1)
+	if err != nil {
+		return err
+	}
+	return fn(flags, s.s2)
+}
+
+func (s *IntersectShader) AppendShader(glsl *sdf.Shader) error {
+	glsl.Name = append(glsl.Name, "intersect_"...)
+	id1Start := len(glsl.Name)
+	err := glsl.AppendChildName(s.s1)
+	if err != nil {
+		return err
+	}
+	id2Start := len(glsl.Name)
+	err = glsl.AppendChildName(s.s2)
+	if err != nil {
+		return err
+	}
+	glsl.Body = append(glsl.Body, "return max("...)
+	glsl.Body = append(glsl.Body, glsl.Name[id1Start:id2Start]...)
+	glsl.Body = append(glsl.Body, "(p),"...)
+	glsl.Body = append(glsl.Body, glsl.Name[id2Start:]...)
+	glsl.Body = append(glsl.Body, "(p));"...)
+	return nil
+}
+
+// Difference returns s1 with s2 carved out of it.
+func Difference(s1, s2 sdf.Shaderer) sdf.Shaderer {
+	if s1 == nil || s2 == nil {
+		panic("nil object")
+	}
+	return &DifferenceShader{
+		s1: s1,
+		s2: s2,
+	}
+}
+
+type DifferenceShader struct {
+	s1, s2 sdf.Shaderer
+	bb     boundsCache
+}
+
+// Bounds returns the bounds of the minuend since subtracting can only shrink it.
+func (s *DifferenceShader) Bounds() (vmin, vmax ms3.Vec) {
+	if s.bb.ok {
+		return s.bb.min, s.bb.max
+	}
+	return s.bb.set(s.s1.Bounds())
+}
+
+func (s *DifferenceShader) ForEachChild(flags int, fn func(flags int, s sdf.Shaderer) error) error {
+	err := fn(flags, s.s1)
+	if err != nil {
+		return err
+	}
+	return fn(flags, s.s2)
+}
+
+func (s *DifferenceShader) AppendShader(glsl *sdf.Shader) error {
+	glsl.Name = append(glsl.Name, "difference_"...)
+	id1Start := len(glsl.Name)
+	err := glsl.AppendChildName(s.s1)
+	if err != nil {
+		return err
+	}
+	id2Start := len(glsl.Name)
+	err = glsl.AppendChildName(s.s2)
+	if err != nil {
+		return err
+	}
+	glsl.Body = append(glsl.Body, "return max("...)
+	glsl.Body = append(glsl.Body, glsl.Name[id1Start:id2Start]...)
+	glsl.Body = append(glsl.Body, "(p),-"...)
+	glsl.Body = append(glsl.Body, glsl.Name[id2Start:]...)
+	glsl.Body = append(glsl.Body, "(p));"...)
+	return nil
+}
+
+func NewSphere(radius float32) (sdf.Shaderer, error) {
 	return &Sphere{R: radius}, nil
 }
 
-func Translate(s SDFShaderer, to Vec) SDFShaderer {
+func Translate(s sdf.Shaderer, to ms3.Vec) sdf.Shaderer {
 	return &TranslateShader{
 		s: s,
 		p: to,
@@ -263,41 +448,38 @@ func Translate(s SDFShaderer, to Vec) SDFShaderer {
 }
 
 type TranslateShader struct {
-	s SDFShaderer
-	p Vec
+	s  sdf.Shaderer
+	p  ms3.Vec
+	bb boundsCache
 }
 
-func (ts *TranslateShader) Bounds() (min, max Vec) {
+func (ts *TranslateShader) Bounds() (min, max ms3.Vec) {
+	if ts.bb.ok {
+		return ts.bb.min, ts.bb.max
+	}
 	min, max = ts.s.Bounds()
-	min = Vec{X: min.X + ts.p.X, Y: min.Y + ts.p.Y, Z: min.X + ts.p.Z}
-	max = Vec{X: max.X + ts.p.X, Y: max.Y + ts.p.Y, Z: max.X + ts.p.Z}
-	return min, max
+	min = ms3.Vec{X: min.X + ts.p.X, Y: min.Y + ts.p.Y, Z: min.Z + ts.p.Z}
+	max = ms3.Vec{X: max.X + ts.p.X, Y: max.Y + ts.p.Y, Z: max.Z + ts.p.Z}
+	return ts.bb.set(min, max)
 }
 
-func (s *TranslateShader) ForEachChild(flags int, fn func(flags int, s SDFShaderer) error) error {
+func (s *TranslateShader) ForEachChild(flags int, fn func(flags int, s sdf.Shaderer) error) error {
 	return fn(flags, s.s)
 }
 
-func (ts *TranslateShader) AppendShader(glsl *SDFShader) error {
+func (ts *TranslateShader) AppendShader(glsl *sdf.Shader) error {
 	glsl.Name = append(glsl.Name, "translate"...)
-	glsl.Name = strconv.AppendFloat(glsl.Name, float64(ts.p.X), fltFmtByte, fltPrec, 32)
-	glsl.Name = strconv.AppendFloat(glsl.Name, float64(ts.p.Y), fltFmtByte, fltPrec, 32)
-	glsl.Name = strconv.AppendFloat(glsl.Name, float64(ts.p.Z), fltFmtByte, fltPrec, 32)
-	for {
-		idx := bytes.IndexByte(glsl.Name, '.')
-		if idx < 0 {
-			break
-		}
-		glsl.Name[idx] = 'p'
-	}
+	glsl.Name = appendNameFloat(glsl.Name, ts.p.X)
+	glsl.Name = append(glsl.Name, '_')
+	glsl.Name = appendNameFloat(glsl.Name, ts.p.Y)
+	glsl.Name = append(glsl.Name, '_')
+	glsl.Name = appendNameFloat(glsl.Name, ts.p.Z)
 	glsl.Name = append(glsl.Name, '_')
 	idStart := len(glsl.Name)
-	body := glsl.Body
-	err := ts.s.AppendShader(glsl)
+	err := glsl.AppendChildName(ts.s)
 	if err != nil {
 		return err
 	}
-	glsl.Body = glsl.Body[:len(body)]
 	glsl.Body = append(glsl.Body, "return "...)
 	glsl.Body = append(glsl.Body, glsl.Name[idStart:]...)
 	glsl.Body = append(glsl.Body, "(p - vec3("...)
@@ -310,79 +492,75 @@ func (ts *TranslateShader) AppendShader(glsl *SDFShader) error {
 	return nil
 }
 
-func writeProgram(w io.Writer, obj SDFShaderer) (n int, err error) {
-	var scratch SDFShader
-	obj.AppendShader(&scratch)
-	topname := string(scratch.Name)
-
-	Children := []SDFShaderer{obj}
-	nextChild := 0
-	for len(Children[nextChild:]) > 0 {
-		prev := len(Children)
-		for _, obj := range Children[nextChild:] {
-			fmt.Printf("%T\n", obj)
-			obj.ForEachChild(0, func(flags int, s SDFShaderer) error {
-				Children = append(Children, s)
-				return nil
-			})
-		}
-		nextChild = prev
-	}
-	const programHeader = `#shader compute
-#version 430
-`
-	n, err = w.Write([]byte(programHeader))
-
-	if err != nil {
-		return n, err
+// Rotate rotates s by the rotation represented by quaternion q.
+func Rotate(s sdf.Shaderer, q ms3.Quat) sdf.Shaderer {
+	if s == nil {
+		panic("nil object")
 	}
-
-	for i := len(Children) - 1; i >= 0; i-- {
-		ngot, err := writeShader(w, Children[i], &scratch)
-		n += ngot
-		if err != nil {
-			return n, err
-		}
+	return &RotateShader{
+		s: s,
+		m: ms3.RotatingMat3(q.Unit()),
 	}
-	programMain := fmt.Sprintf(`
+}
 
-layout(local_size_x = 1, local_size_y = 1, local_size_z = 1) in;
-layout(rgba32f, binding = 0) uniform image2D in_tex;
-// The binding argument refers to the textures Unit.
-layout(r32f, binding = 1) uniform image2D out_tex;
+type RotateShader struct {
+	s  sdf.Shaderer
+	m  ms3.Mat3 // Rotation matrix.
+	bb boundsCache
+}
 
-void main() {
-	// get position to read/write data from.
-	ivec2 pos = ivec2( gl_GlobalInvocationID.xy );
-	// Get SDF position value.
-	vec3 p = imageLoad( in_tex, pos ).rgb;
-	float distance = %s(p);
-	// store new value in image
-	imageStore( out_tex, pos, vec4( distance, 0.0, 0.0, 0.0 ) );
+// Bounds rotates the child's bounding box and refits it to be axis aligned.
+func (rs *RotateShader) Bounds() (min, max ms3.Vec) {
+	if rs.bb.ok {
+		return rs.bb.min, rs.bb.max
+	}
+	min, max = rs.s.Bounds()
+	box := rs.m.AsMat4().MulBox(ms3.Box{Min: min, Max: max})
+	return rs.bb.set(box.Min, box.Max)
 }
-	`, topname)
 
-	ngot, err := w.Write([]byte(programMain))
-	return n + ngot, err
+func (rs *RotateShader) ForEachChild(flags int, fn func(flags int, s sdf.Shaderer) error) error {
+	return fn(flags, rs.s)
 }
 
-func writeShader(w io.Writer, s SDFShaderer, scratch *SDFShader) (int, error) {
-	scratch.Name = scratch.Name[:0]
-	scratch.Body = scratch.Body[:0]
-	scratch.Name = append(scratch.Name, "float "...)
-	err := s.AppendShader(scratch)
-	if err != nil {
-		return 0, err
+func (rs *RotateShader) AppendShader(glsl *sdf.Shader) error {
+	m := rs.m.Array()
+	glsl.Name = append(glsl.Name, "rotate"...)
+	for _, v := range m {
+		glsl.Name = appendNameFloat(glsl.Name, v)
+		glsl.Name = append(glsl.Name, '_')
 	}
-	scratch.Name = append(scratch.Name, "(vec3 p) {\n"...)
-
-	scratch.Body = append(scratch.Body, "\n}\n\n"...)
-	n, err := w.Write(scratch.Name)
+	idStart := len(glsl.Name)
+	err := glsl.AppendChildName(rs.s)
 	if err != nil {
-		return n, err
+		return err
+	}
+	glsl.Body = append(glsl.Body, "return "...)
+	glsl.Body = append(glsl.Body, glsl.Name[idStart:]...)
+	// GLSL matrix constructors are column major so passing the row major
+	// rotation matrix yields its transpose, which is the inverse rotation.
+	glsl.Body = append(glsl.Body, "(mat3("...)
+	for i, v := range m {
+		if i != 0 {
+			glsl.Body = append(glsl.Body, ',')
+		}
+		glsl.Body = strconv.AppendFloat(glsl.Body, float64(v), 'f', fltPrec, 32)
 	}
-	n2, err := w.Write(scratch.Body)
-	return n + n2, err
+	glsl.Body = append(glsl.Body, ") * p);"...)
+	return nil
+}
+
+// boundsCache memoizes the result of a Bounds call so that repeated queries
+// on a composite SDF do not recurse through the whole tree. SDF trees are
+// immutable after construction so the cache never needs invalidation.
+type boundsCache struct {
+	min, max ms3.Vec
+	ok       bool
+}
+
+func (bc *boundsCache) set(min, max ms3.Vec) (ms3.Vec, ms3.Vec) {
+	bc.min, bc.max, bc.ok = min, max, true
+	return min, max
 }
 
 func minf(a, b float32) float32 {
@@ -394,7 +572,7 @@ func maxf(a, b float32) float32 {
 }
 
 // norm is equivalent to glsl `length` call.
-func norm(pos Vec) float32 {
+func norm(pos ms3.Vec) float32 {
 	r1 := math.Hypot(float64(pos.X), float64(pos.Y))
 	r2 := math.Hypot(r1, float64(pos.Z))
 	return float32(r2)
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/soypat/glgl/math/ms3"
+	"github.com/soypat/glgl/v4.6-core/glgl/sdf"
+)
+
+func TestTranslateSphere(t *testing.T) {
+	const tol = 1e-6
+	const r = 0.5
+	to := ms3.Vec{X: 2, Y: -1, Z: 3}
+	sphere, _ := NewSphere(r)
+	s := Translate(sphere, to)
+	min, max := s.Bounds()
+	wantMin := ms3.Vec{X: 1.5, Y: -1.5, Z: 2.5}
+	wantMax := ms3.Vec{X: 2.5, Y: -0.5, Z: 3.5}
+	if !ms3.EqualElem(min, wantMin, tol) || !ms3.EqualElem(max, wantMax, tol) {
+		t.Errorf("want bounds %v..%v, got %v..%v", wantMin, wantMax, min, max)
+	}
+	// Bounds are memoized so modifying the child after the first call has no effect.
+	sphere.(*Sphere).R = 10
+	min2, max2 := s.Bounds()
+	if min2 != min || max2 != max {
+		t.Errorf("want cached bounds %v..%v, got %v..%v", min, max, min2, max2)
+	}
+	sphere.(*Sphere).R = r
+
+	name, body := appendShader(t, s)
+	if name != "translate2_n1_3_sphere0p5" {
+		t.Errorf("unexpected name %q", name)
+	}
+	// Evaluate the generated GLSL on the CPU by reading back its constants.
+	_, sphereBody := appendShader(t, sphere)
+	radius := parseFloats(t, sphereBody, "length(p)-", ";")
+	offset := parseFloats(t, body, "vec3(", ")")
+	if len(radius) != 1 || len(offset) != 3 {
+		t.Fatalf("unexpected generated bodies %q and %q", sphereBody, body)
+	}
+	for _, p := range []ms3.Vec{{}, to, {X: 2.5, Y: -1, Z: 3}, {X: -4, Y: 7, Z: 0.25}} {
+		q := ms3.Sub(p, ms3.Vec{X: offset[0], Y: offset[1], Z: offset[2]})
+		got := norm(q) - radius[0]
+		want := ms3.Norm(ms3.Sub(p, to)) - r
+		if math.Abs(float64(got-want)) > tol {
+			t.Errorf("distance at %v: want %v, generated shader gives %v", p, want, got)
+		}
+	}
+}
+
+// appendShader returns the function name and body s writes to an empty shader.
+func appendShader(t *testing.T, s sdf.Shaderer) (name, body string) {
+	t.Helper()
+	var glsl sdf.Shader
+	err := s.AppendShader(&glsl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(glsl.Name), string(glsl.Body)
+}
+
+// parseFloats parses the comma separated numbers in src between the first
+// occurrence of prefix and the following occurrence of suffix.
+func parseFloats(t *testing.T, src, prefix, suffix string) []float32 {
+	t.Helper()
+	_, after, ok := strings.Cut(src, prefix)
+	if !ok {
+		t.Fatalf("%q not found in %q", prefix, src)
+	}
+	list, _, ok := strings.Cut(after, suffix)
+	if !ok {
+		t.Fatalf("%q not found in %q", suffix, src)
+	}
+	var floats []float32
+	for _, field := range strings.Split(list, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		floats = append(floats, float32(v))
+	}
+	return floats
+}
+
+func TestRotateSphere(t *testing.T) {
+	const tol = 1e-5
+	sphere, _ := NewSphere(0.5)
+	moved := Translate(sphere, ms3.Vec{X: 1})
+	// Half turn about Z has an exactly representable rotation matrix diag(-1,-1,1).
+	name, body := appendShader(t, Rotate(moved, ms3.Quat{K: 1}))
+	// Matrix entries are separated so names of different rotations cannot collide.
+	const wantName = "rotaten1_0_0_0_n1_0_0_0_1_translate1_0_0_sphere0p5"
+	if name != wantName {
+		t.Errorf("want name %q, got %q", wantName, name)
+	}
+	const wantBody = "return translate1_0_0_sphere0p5(mat3(-1.00000000,0.00000000,0.00000000,0.00000000,-1.00000000,0.00000000,0.00000000,0.00000000,1.00000000) * p);"
+	if body != wantBody {
+		t.Errorf("want body %q, got %q", wantBody, body)
+	}
+	// Rounding error of general rotations must not leak exponents or signs into names.
+	s := Rotate(moved, ms3.RotationQuat(math.Pi/2, ms3.Vec{Z: 1}))
+	name, _ = appendShader(t, s)
+	if strings.ContainsAny(name, ".-+") {
+		t.Errorf("name %q is not a valid identifier", name)
+	}
+	// Bounds of the rotated child are refit to be axis aligned.
+	min, max := s.Bounds()
+	wantMin := ms3.Vec{X: -0.5, Y: 0.5, Z: -0.5}
+	wantMax := ms3.Vec{X: 0.5, Y: 1.5, Z: 0.5}
+	if !ms3.EqualElem(min, wantMin, tol) || !ms3.EqualElem(max, wantMax, tol) {
+		t.Errorf("want bounds %v..%v, got %v..%v", wantMin, wantMax, min, max)
+	}
+}
+
+func TestIntersectDifference(t *testing.T) {
+	const tol = 1e-6
+	big, _ := NewSphere(1)
+	small, _ := NewSphere(0.5)
+	overlapping := Translate(small, ms3.Vec{X: 1})
+	disjoint := Translate(small, ms3.Vec{X: 3, Y: 2})
+	for _, test := range []struct {
+		name             string
+		s                sdf.Shaderer
+		wantMin, wantMax ms3.Vec
+	}{
+		{
+			name:    "overlapping intersect",
+			s:       Intersect(big, overlapping),
+			wantMin: ms3.Vec{X: 0.5, Y: -0.5, Z: -0.5},
+			wantMax: ms3.Vec{X: 1, Y: 0.5, Z: 0.5},
+		},
+		{
+			// Empty overlap collapses to the center of the inverted overlap box.
+			name:    "disjoint intersect",
+			s:       Intersect(big, disjoint),
+			wantMin: ms3.Vec{X: 1.75, Y: 1.25},
+			wantMax: ms3.Vec{X: 1.75, Y: 1.25},
+		},
+		{
+			name:    "difference",
+			s:       Difference(big, overlapping),
+			wantMin: ms3.Vec{X: -1, Y: -1, Z: -1},
+			wantMax: ms3.Vec{X: 1, Y: 1, Z: 1},
+		},
+	} {
+		min, max := test.s.Bounds()
+		if !ms3.EqualElem(min, test.wantMin, tol) || !ms3.EqualElem(max, test.wantMax, tol) {
+			t.Errorf("%s: want bounds %v..%v, got %v..%v", test.name, test.wantMin, test.wantMax, min, max)
+		}
+	}
+
+	name, body := appendShader(t, Intersect(big, overlapping))
+	if name != "intersect_sphere1translate1_0_0_sphere0p5" {
+		t.Errorf("unexpected intersect name %q", name)
+	}
+	if body != "return max(sphere1(p),translate1_0_0_sphere0p5(p));" {
+		t.Errorf("unexpected intersect body %q", body)
+	}
+	name, body = appendShader(t, Difference(big, overlapping))
+	if name != "difference_sphere1translate1_0_0_sphere0p5" {
+		t.Errorf("unexpected difference name %q", name)
+	}
+	if body != "return max(sphere1(p),-translate1_0_0_sphere0p5(p));" {
+		t.Errorf("unexpected difference body %q", body)
+	}
+}
+
+func TestSmoothUnion(t *testing.T) {
+	const tol = 1e-6
+	const k = 0.25
+	s1, _ := NewSphere(1)
+	s2, _ := NewSphere(0.5)
+	s2 = Translate(s2, ms3.Vec{Y: 2})
+	s := SmoothUnion(s1, s2, k)
+	// Union bounds are expanded by k to enclose the blended seam.
+	min, max := s.Bounds()
+	wantMin := ms3.Vec{X: -1 - k, Y: -1 - k, Z: -1 - k}
+	wantMax := ms3.Vec{X: 1 + k, Y: 2.5 + k, Z: 1 + k}
+	if !ms3.EqualElem(min, wantMin, tol) || !ms3.EqualElem(max, wantMax, tol) {
+		t.Errorf("want bounds %v..%v, got %v..%v", wantMin, wantMax, min, max)
+	}
+	name, body := appendShader(t, s)
+	if name != "smoothunion0p25_sphere1translate0_2_0_sphere0p5" {
+		t.Errorf("unexpected name %q", name)
+	}
+	const wantBody = "float k = 0.25000000;\n" +
+		"float d1 = sphere1(p);\n" +
+		"float d2 = translate0_2_0_sphere0p5(p);\n" +
+		"float h = clamp(0.5+0.5*(d2-d1)/k, 0.0, 1.0);\n" +
+		"return mix(d2, d1, h) - k*h*(1.0-h);"
+	if body != wantBody {
+		t.Errorf("want body %q, got %q", wantBody, body)
+	}
+	// The blend is below the plain union near the seam and equal to it far from it.
+	blend := func(d1, d2 float32) float32 {
+		kk := parseFloats(t, body, "float k = ", ";")[0]
+		h := float32(math.Max(0, math.Min(1, float64(0.5+0.5*(d2-d1)/kk))))
+		return d2*(1-h) + d1*h - kk*h*(1-h)
+	}
+	if got := blend(0.1, 0.1); got >= 0.1 {
+		t.Errorf("want blended distance below 0.1 on seam, got %v", got)
+	}
+	if got := blend(0.1, 2); math.Abs(float64(got-0.1)) > tol {
+		t.Errorf("want distance 0.1 away from seam, got %v", got)
+	}
+}
@@ -20,21 +20,24 @@ var (
 )
 
 func main() {
-	err := run()
+	err := run(".")
 	if err != nil {
 		log.Fatal(err)
 	}
 	log.Println("generated files")
 }
 
-func run() error {
+// run generates the float64 packages from their float32 counterparts
+// into the dstRoot directory.
+func run(dstRoot string) error {
 	for _, rep := range replaceWithF64 {
 		files, err := srcmath.ReadDir(rep[0])
 		if err != nil {
 			return err
 		}
-		os.RemoveAll(rep[1])
-		err = os.MkdirAll(rep[1], 0777)
+		dstDir := filepath.Join(dstRoot, rep[1])
+		os.RemoveAll(dstDir)
+		err = os.MkdirAll(dstDir, 0777)
 		if err != nil {
 			return err
 		}
@@ -45,7 +48,7 @@ func run() error {
 				return err
 			}
 			newName := strings.ReplaceAll(file.Name(), rep[0], rep[1])
-			dst, err := os.Create(filepath.Join(rep[1], newName))
+			dst, err := os.Create(filepath.Join(dstDir, newName))
 			if err != nil {
 				return err
 			}
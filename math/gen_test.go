@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGeneratedUpToDate checks the float64 packages match the result of
+// generating them from the float32 packages. Run `go run gen.go` to fix.
+func TestGeneratedUpToDate(t *testing.T) {
+	dstRoot := t.TempDir()
+	err := run(dstRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rep := range replaceWithF64 {
+		generated, err := os.ReadDir(filepath.Join(dstRoot, rep[1]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		existing, err := os.ReadDir(rep[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(generated) != len(existing) {
+			t.Errorf("%s: want %d generated files, got %d files", rep[1], len(generated), len(existing))
+		}
+		for _, file := range generated {
+			want, err := os.ReadFile(filepath.Join(dstRoot, rep[1], file.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(filepath.Join(rep[1], file.Name()))
+			if err != nil {
+				t.Errorf("%s: missing generated file: %s", rep[1], err)
+				continue
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s: generated file %s out of date", rep[1], file.Name())
+			}
+		}
+	}
+}
@@ -1,6 +1,29 @@
 package internal
 
+import "math"
+
 const (
 	Smallfloat32 = 1e-5
 	Smallfloat64 = 1e-8
 )
+
+// FastInvSqrtfloat32 approximates 1/sqrt(x) using the bit level hack popularized
+// by Quake III refined by the given number of Newton-Raphson iterations.
+func FastInvSqrtfloat32(x float32, iterations int) float32 {
+	xhalf := 0.5 * x
+	y := math.Float32frombits(0x5f375a86 - (math.Float32bits(x) >> 1))
+	for ; iterations > 0; iterations-- {
+		y *= 1.5 - xhalf*y*y
+	}
+	return y
+}
+
+// FastInvSqrtfloat64 is the 64 bit counterpart of [FastInvSqrtfloat32].
+func FastInvSqrtfloat64(x float64, iterations int) float64 {
+	xhalf := 0.5 * x
+	y := math.Float64frombits(0x5fe6eb50c7b537a9 - (math.Float64bits(x) >> 1))
+	for ; iterations > 0; iterations-- {
+		y *= 1.5 - xhalf*y*y
+	}
+	return y
+}
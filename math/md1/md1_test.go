@@ -0,0 +1,131 @@
+// DO NOT EDIT.
+// This file was generated automatically
+// from gen.go. Please do not edit this file.
+
+package md1
+
+import (
+	"testing"
+
+	math "math"
+)
+
+func TestNewtonRaphsonSolver(t *testing.T) {
+	solver := DefaultNewtonRaphsonSolver()
+	for _, test := range []struct {
+		x0   float64
+		f    func(float64) float64
+		want float64
+	}{
+		{x0: 1, f: func(x float64) float64 { return x*x - 2 }, want: math.Sqrt(2)},
+		{x0: -1, f: func(x float64) float64 { return x*x - 2 }, want: -math.Sqrt(2)},
+		{x0: 0.5, f: func(x float64) float64 { return math.Cos(x) - x }, want: 0.7390851},
+		{x0: 3, f: func(x float64) float64 { return x*x*x - 8 }, want: 2},
+	} {
+		got, convergedIn := solver.Root(test.x0, test.f)
+		if convergedIn <= 0 {
+			t.Errorf("root search from %v did not converge (%d)", test.x0, convergedIn)
+		}
+		if !EqualWithinAbs(got, test.want, 1e-4) {
+			t.Errorf("root search from %v want %v, got %v", test.x0, test.want, got)
+		}
+	}
+	// Clamped root search.
+	solver.RootLims = [2]float64{0, 10}
+	got, convergedIn := solver.Root(1, func(x float64) float64 { return x*x - 2 })
+	if convergedIn <= 0 || !EqualWithinAbs(got, math.Sqrt(2), 1e-4) {
+		t.Errorf("clamped root search want %v, got %v (%d)", math.Sqrt(2), got, convergedIn)
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	const tol = 1e-6
+	for _, test := range []struct {
+		got, want float64
+	}{
+		{got: Sign(-3), want: -1},
+		{got: Sign(0), want: 0},
+		{got: Sign(2), want: 1},
+		{got: Clamp(-1, 0, 1), want: 0},
+		{got: Clamp(0.5, 0, 1), want: 0.5},
+		{got: Clamp(2, 0, 1), want: 1},
+		{got: Interp(2, 4, 0.25), want: 2.5},
+		{got: InterpClamped(2, 4, 0.25), want: 2.5},
+		{got: InterpClamped(2, 4, -1), want: 2},
+		{got: InterpClamped(2, 4, 3), want: 4},
+		{got: Damp(3, 1, 0, 0.5), want: 3},
+		{got: Damp(3, 1, 2, 0.5), want: 1 + 2*math.Exp(-1)},
+		{got: Damp(Damp(3, 1, 2, 0.25), 1, 2, 0.25), want: Damp(3, 1, 2, 0.5)},
+		{got: SmoothStep(0, 1, -1), want: 0},
+		{got: SmoothStep(0, 1, 0.5), want: 0.5},
+		{got: SmoothStep(0, 1, 2), want: 1},
+		{got: Wrap(5.5, 0, 2), want: 1.5},
+		{got: Wrap(-0.5, 0, 2), want: 1.5},
+		{got: Wrap(2, 0, 2), want: 0},
+		{got: Wrap(-3, -1, 1), want: -1},
+		{got: PingPong(0.5, 2), want: 0.5},
+		{got: PingPong(3, 2), want: 1},
+		{got: PingPong(4, 2), want: 0},
+		{got: PingPong(-1, 2), want: 1},
+		{got: WrapAngle(-math.Pi), want: math.Pi},
+		{got: WrapAngle(3 * math.Pi / 2), want: -math.Pi / 2},
+		{got: WrapAngle(0.5), want: 0.5},
+	} {
+		if !EqualWithinAbs(test.got, test.want, tol) {
+			t.Errorf("want %v, got %v", test.want, test.got)
+		}
+	}
+}
+
+func TestEqualWithin(t *testing.T) {
+	for _, test := range []struct {
+		a, b, absTol, relTol  float64
+		wantRel, wantAbsOrRel bool
+	}{
+		{a: 1e6, b: 1e6 + 64, relTol: 1e-4, wantRel: true, wantAbsOrRel: true},
+		{a: 1e6, b: 1.1e6, relTol: 1e-4},
+		{a: -2, b: 2, relTol: 1e-4},
+		{a: 0, b: 1e-9, absTol: 1e-6, relTol: 1e-4, wantAbsOrRel: true},
+		{a: 0, b: 0, wantRel: true, wantAbsOrRel: true},
+	} {
+		if got := EqualWithinRel(test.a, test.b, test.relTol); got != test.wantRel {
+			t.Errorf("EqualWithinRel(%v, %v, %v) want %v", test.a, test.b, test.relTol, test.wantRel)
+		}
+		if got := EqualWithinAbsOrRel(test.a, test.b, test.absTol, test.relTol); got != test.wantAbsOrRel {
+			t.Errorf("EqualWithinAbsOrRel(%v, %v, %v, %v) want %v", test.a, test.b, test.absTol, test.relTol, test.wantAbsOrRel)
+		}
+	}
+}
+
+func TestFastInvSqrt(t *testing.T) {
+	for x := float64(1e-6); x < 1e6; x *= 1.1 {
+		want := 1 / math.Sqrt(x)
+		if got := FastInvSqrt(x); !EqualWithinRel(got, want, 2e-3) {
+			t.Errorf("FastInvSqrt(%v) want %v, got %v", x, want, got)
+		}
+		if got := FastInvSqrt1(x); !EqualWithinRel(got, want, 5e-6) {
+			t.Errorf("FastInvSqrt1(%v) want %v, got %v", x, want, got)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	// Values computed with reference PCG hash implementation.
+	for _, test := range []struct {
+		seed, want uint32
+	}{
+		{seed: 0, want: 129708002},
+		{seed: 1, want: 2831084092},
+		{seed: 12345, want: 4099845390},
+	} {
+		if got := Hash(test.seed); got != test.want {
+			t.Errorf("Hash(%d) want %d, got %d", test.seed, test.want, got)
+		}
+	}
+	for seed := uint32(0); seed < 1000; seed++ {
+		f := HashToFloat(seed)
+		if f < 0 || f >= 1 {
+			t.Fatalf("HashToFloat(%d) out of [0,1): %v", seed, f)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// DO NOT EDIT.
+// This file was generated automatically
+// from gen.go. Please do not edit this file.
+
+package md2
+
+import (
+	math "math"
+)
+
+// AppendGrid splits the argument bounds [Box] x,y axes by nx,ny, respectively
+// and generates points on the vertices generated by the division and appends them to dst, returning the result.
+// All box edges are vertices in result. AppendGrid panics if it receives a dimension less than 2.
+//
+// Indexing is x-major:
+//
+//	grid := ms2.AppendGrid(nil, domain, nx, ny)
+//	ix, iy := 1, 0
+//	pos := grid[iy*nx + ix]
+func AppendGrid(dst []Vec, domain Box, nx, ny int) []Vec {
+	if nx <= 1 || ny <= 1 {
+		panic("AppendGrid needs more grid subdivisions")
+	}
+	nxyz := Vec{X: float64(nx - 1), Y: float64(ny - 1)}
+	dxyz := DivElem(domain.Size(), nxyz)
+	var xyz Vec
+	for j := 0; j < ny; j++ {
+		xyz.Y = domain.Min.Y + dxyz.Y*float64(j)
+		for i := 0; i < nx; i++ {
+			xyz.X = domain.Min.X + dxyz.X*float64(i)
+			dst = append(dst, xyz)
+		}
+	}
+	return dst
+}
+
+// GridSubdomain facilitates obtaining the set of points in a grid shared between a domain box
+// and a subdomain box contained within the domain box. Points of the grid should
+// be ordered in x-major format, like the values returned by [AppendGrid].
+//
+//	istart, nxSub, nySub := GridSubdomain(domain, nx, ny, subdomain)
+//	for iy := 0; iy < nySub; iy++ {
+//		off := istart + iy*nx
+//		for ix := 0; ix < nxSub; ix++ {
+//			pointInSubdomain := grid[off+ix]
+//			// do something with pointInSubdomain.
+//		}
+//	}
+func GridSubdomain(domain Box, nxDomain, nyDomain int, subdomain Box) (iStart, nxSub, nySub int) {
+	if !domain.ContainsBox(subdomain) {
+		panic("subdomain not contained in domain")
+	}
+	dx := (domain.Max.X - domain.Min.X) / float64(nxDomain-1)
+	dy := (domain.Max.Y - domain.Min.Y) / float64(nyDomain-1)
+
+	off := Sub(subdomain.Min, domain.Min)
+	ix0 := iceil(off.X / dx)
+	iy0 := iceil(off.Y / dy)
+	iStart = ix0 + iy0*nxDomain
+
+	offEnd := Sub(subdomain.Max, domain.Min)
+	ixf := int(offEnd.X / dx)
+	iyf := int(offEnd.Y / dy)
+
+	nxSub = ixf - ix0 + 1
+	nySub = iyf - iy0 + 1
+	return iStart, nxSub, nySub
+}
+
+// GridIndices returns the indices that stitch a grid of nx by ny vertices generated
+// by [AppendGrid] into triangles to be drawn with GL_TRIANGLES. Two triangles are
+// generated per grid cell, both with counter-clockwise winding when y points up.
+// GridIndices panics if it receives a dimension less than 2.
+func GridIndices(nx, ny int) []uint32 {
+	if nx <= 1 || ny <= 1 {
+		panic("GridIndices needs more grid subdivisions")
+	}
+	indices := make([]uint32, 0, 6*(nx-1)*(ny-1))
+	for j := 0; j < ny-1; j++ {
+		for i := 0; i < nx-1; i++ {
+			v0 := uint32(j*nx + i) // Bottom left vertex of cell.
+			v1 := v0 + 1
+			v2 := v0 + uint32(nx)
+			v3 := v2 + 1
+			indices = append(indices, v0, v1, v3, v0, v3, v2)
+		}
+	}
+	return indices
+}
+
+// GridStripIndices is like [GridIndices] but returns indices for drawing the grid
+// as a single GL_TRIANGLE_STRIP. Rows of the grid are joined by degenerate triangles
+// which are not rasterized.
+func GridStripIndices(nx, ny int) []uint32 {
+	if nx <= 1 || ny <= 1 {
+		panic("GridStripIndices needs more grid subdivisions")
+	}
+	indices := make([]uint32, 0, 2*nx*(ny-1)+2*(ny-2))
+	for j := 0; j < ny-1; j++ {
+		if j > 0 {
+			// Degenerate triangles: repeat last vertex of previous row and first of this row.
+			indices = append(indices, indices[len(indices)-1], uint32((j+1)*nx))
+		}
+		for i := 0; i < nx; i++ {
+			indices = append(indices, uint32((j+1)*nx+i), uint32(j*nx+i))
+		}
+	}
+	return indices
+}
+
+func iceil(f float64) int {
+	return int(math.Ceil(f))
+}
@@ -84,6 +84,29 @@ func MulMatVecTrans(m Mat2, v Vec) (result Vec) {
 	return result
 }
 
+// MulBox transforms the corners of b by m and returns the axis aligned box that contains them.
+func (m Mat2) MulBox(b Box) Box {
+	return MulBoxAffine(m, Vec{}, b)
+}
+
+// MulBoxAffine transforms the corners of b by the affine transform m*p+offset
+// and returns the axis aligned box that contains them.
+func MulBoxAffine(m Mat2, offset Vec, b Box) Box {
+	// Equivalent to transforming b.Vertices() and refitting.
+	r := Vec{X: m.x00, Y: m.x10}
+	u := Vec{X: m.x01, Y: m.x11}
+	xa := Scale(b.Min.X, r)
+	xb := Scale(b.Max.X, r)
+	ya := Scale(b.Min.Y, u)
+	yb := Scale(b.Max.Y, u)
+	xa, xb = MinElem(xa, xb), MaxElem(xa, xb)
+	ya, yb = MinElem(ya, yb), MaxElem(ya, yb)
+	return Box{
+		Min: Add(xa, Add(ya, offset)),
+		Max: Add(xb, Add(yb, offset)),
+	}
+}
+
 // ScaleMat2 multiplies each 2x2 matrix component by a scalar.
 func ScaleMat2(a Mat2, k float64) Mat2 {
 	return Mat2{
@@ -0,0 +1,67 @@
+// DO NOT EDIT.
+// This file was generated automatically
+// from gen.go. Please do not edit this file.
+
+package md2
+
+import (
+	math "math"
+	ms3 "github.com/soypat/glgl/math/md3"
+)
+
+// Transform2D is a 2D affine transformation composed of a scaling followed
+// by a rotation followed by a translation. The zero value is not a valid
+// transform, use [IdentityTransform2D] to create a transform that does nothing.
+type Transform2D struct {
+	Position Vec
+	// Rotation is the counter-clockwise rotation in radians.
+	Rotation float64
+	Scale    Vec
+}
+
+// IdentityTransform2D returns the Transform2D which leaves points unchanged.
+func IdentityTransform2D() Transform2D {
+	return Transform2D{Scale: Vec{X: 1, Y: 1}}
+}
+
+// Matrix returns the 3x3 affine matrix equivalent of the transform which
+// operates on 2D points in homogeneous coordinates (x, y, 1), i.e:
+//
+//	| r00*sx  r01*sy  tx |
+//	| r10*sx  r11*sy  ty |
+//	|   0       0      1 |
+func (t Transform2D) Matrix() ms3.Mat3 {
+	s, c := math.Sincos(t.Rotation)
+	return ms3.NewMat3([]float64{
+		c * t.Scale.X, -s * t.Scale.Y, t.Position.X,
+		s * t.Scale.X, c * t.Scale.Y, t.Position.Y,
+		0, 0, 1,
+	})
+}
+
+// TransformPoint applies the scaling, rotation and translation to p.
+func (t Transform2D) TransformPoint(p Vec) Vec {
+	return Add(t.Position, MulMatVec(RotationMat2(t.Rotation), MulElem(t.Scale, p)))
+}
+
+// Inverse returns the transform which undoes t. The result
+// is exact only if t's scale is uniform, i.e: Scale.X == Scale.Y.
+func (t Transform2D) Inverse() Transform2D {
+	invScale := DivElem(Vec{X: 1, Y: 1}, t.Scale)
+	return Transform2D{
+		Position: MulElem(invScale, MulMatVec(RotationMat2(-t.Rotation), Scale(-1, t.Position))),
+		Rotation: -t.Rotation,
+		Scale:    invScale,
+	}
+}
+
+// Mul returns the composition of t and child, which is the transform that
+// first applies child and then t. The result is exact only if t's scale
+// is uniform or child has no rotation.
+func (t Transform2D) Mul(child Transform2D) Transform2D {
+	return Transform2D{
+		Position: t.TransformPoint(child.Position),
+		Rotation: t.Rotation + child.Rotation,
+		Scale:    MulElem(t.Scale, child.Scale),
+	}
+}
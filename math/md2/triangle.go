@@ -5,6 +5,9 @@
 package md2
 
 import (
+	"math/rand"
+	"slices"
+
 	math "math"
 )
 
@@ -32,6 +35,45 @@ func (t Triangle) Area() float64 {
 	return math.Sqrt(A) / 4
 }
 
+// RandomPoint returns a uniformly distributed random point within the triangle.
+func (t Triangle) RandomPoint(rng *rand.Rand) Vec {
+	// Barycentric coordinates (1-sqrt(r1), sqrt(r1)*(1-r2), sqrt(r1)*r2) are
+	// uniformly distributed over the triangle's area.
+	r1 := math.Sqrt(float64(rng.Float64()))
+	r2 := float64(rng.Float64())
+	p := Scale(1-r1, t[0])
+	p = Add(p, Scale(r1*(1-r2), t[1]))
+	return Add(p, Scale(r1*r2, t[2]))
+}
+
+// SampleMeshSurface returns n uniformly distributed random points on the surface
+// formed by tris. Triangles are chosen with probability proportional to their area.
+// SampleMeshSurface returns nil if n is not positive or the total area of tris is zero.
+func SampleMeshSurface(tris []Triangle, n int, rng *rand.Rand) []Vec {
+	if n <= 0 {
+		return nil
+	}
+	cumulative := make([]float64, len(tris))
+	var total float64
+	for i, tri := range tris {
+		area := tri.Area()
+		if area > 0 { // Guards against NaN areas of degenerate triangles.
+			total += area
+		}
+		cumulative[i] = total
+	}
+	if total == 0 {
+		return nil
+	}
+	points := make([]Vec, n)
+	for i := range points {
+		idx, _ := slices.BinarySearch(cumulative, total*float64(rng.Float64()))
+		idx = min(idx, len(tris)-1)
+		points[i] = tris[idx].RandomPoint(rng)
+	}
+	return points
+}
+
 // longIdx returns index of the longest side. The sides
 // of the triangles are are as follows:
 //   - Side 0 formed by vertices 0 and 1
@@ -99,6 +141,7 @@ func (l Line) Distance(p Vec) float64 {
 // l1, l2, l3 such that l1 ≤ l2 ≤ l3.
 func sort(a, b, c float64) (l1, l2, l3 float64) {
 	// sort-3
+	l1, l2, l3 = a, b, c
 	if l2 < l1 {
 		l1, l2 = l2, l1
 	}
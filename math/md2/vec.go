@@ -34,6 +34,12 @@ func (a Vec) AllNonzero() bool {
 	return a.X != 0 && a.Y != 0
 }
 
+// Equal returns true if a and b are within tol of eachother for each component.
+// It is the method form of [EqualElem].
+func (a Vec) Equal(b Vec, tol float64) bool {
+	return EqualElem(a, b, tol)
+}
+
 // Add returns the vector sum of p and q.
 func Add(p, q Vec) Vec {
 	return Vec{
@@ -90,6 +96,19 @@ func Norm2(p Vec) float64 {
 	return p.X*p.X + p.Y*p.Y
 }
 
+// Distance returns the Euclidean distance between p and q
+//
+//	|p-q| = sqrt((p_x-q_x)^2 + (p_y-q_y)^2).
+func Distance(p, q Vec) float64 {
+	return Norm(Sub(p, q))
+}
+
+// DistanceSquared returns the Euclidean squared distance between p and q.
+// It is cheaper than [Distance] and is suitable for comparing distances.
+func DistanceSquared(p, q Vec) float64 {
+	return Norm2(Sub(p, q))
+}
+
 // Unit returns the unit vector colinear to p.
 // Unit returns {NaN,NaN,NaN} for the zero vector.
 func Unit(p Vec) Vec {
@@ -120,6 +139,19 @@ func MaxElem(a, b Vec) Vec {
 	}
 }
 
+// Centroid returns the arithmetic mean of points. Centroid returns
+// the zero Vec if points is empty.
+func Centroid(points []Vec) Vec {
+	if len(points) == 0 {
+		return Vec{}
+	}
+	var sum Vec
+	for _, p := range points {
+		sum = Add(sum, p)
+	}
+	return Scale(1/float64(len(points)), sum)
+}
+
 // AbsElem returns the vector with components set to their absolute value.
 func AbsElem(a Vec) Vec {
 	return Vec{
@@ -223,6 +255,12 @@ func (v Vec) polar() pol {
 	return pol{Norm(v), math.Atan2(v.Y, v.X)}
 }
 
+// Damp moves current towards target with frame rate independent exponential
+// decay. See [ms1.Damp].
+func Damp(current, target Vec, smoothing, dt float64) Vec {
+	return Add(target, Scale(math.Exp(-smoothing*dt), Sub(current, target)))
+}
+
 // SmoothStepElem performs element-wise smooth cubic hermite
 // interpolation between 0 and 1 when e0 < x < e1.
 func SmoothStepElem(e0, e1, x Vec) Vec {
@@ -249,3 +287,38 @@ func Collinear(a, b, c Vec, tol float64) bool {
 	pb := Unit(Sub(b, c))
 	return math.Abs(Cross(pa, pb)) < tol
 }
+
+// FlattenVecs appends the X and Y components of each vector in vs to dst
+// and returns the result. The result is tightly packed, 2 floats per vector.
+func FlattenVecs(dst []float64, vs []Vec) []float64 {
+	for _, v := range vs {
+		dst = append(dst, v.X, v.Y)
+	}
+	return dst
+}
+
+// AppendVecsFromFloats appends vectors read from tightly packed X, Y
+// components in floats to dst and returns the result. It is the inverse of [FlattenVecs].
+// AppendVecsFromFloats panics if the length of floats is not a multiple of 2.
+func AppendVecsFromFloats(dst []Vec, floats []float64) []Vec {
+	if len(floats)%2 != 0 {
+		panic("floats length not multiple of 2")
+	}
+	for i := 0; i < len(floats); i += 2 {
+		dst = append(dst, Vec{X: floats[i], Y: floats[i+1]})
+	}
+	return dst
+}
+
+// Hash combines the seeds using [ms1.Hash]. It matches the following GLSL function:
+//
+//	uint hash(uvec2 seed) { return hash(seed.x + hash(seed.y)); }
+func Hash(x, y uint32) uint32 {
+	return ms1.Hash(x + ms1.Hash(y))
+}
+
+// HashToFloat returns a pseudo random number in [0,1) derived from [Hash] of the seeds.
+// See [ms1.HashToFloat] for the matching GLSL function.
+func HashToFloat(x, y uint32) float64 {
+	return float64(Hash(x, y)>>8) * (1.0 / 16777216.0)
+}
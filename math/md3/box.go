@@ -5,6 +5,8 @@
 package md3
 
 import (
+	"math/rand"
+
 	math "math"
 )
 
@@ -31,6 +33,20 @@ func NewCenteredBox(center, size Vec) Box {
 	return Box{Min: Sub(center, half), Max: Add(center, half)}
 }
 
+// BoundingBox returns the smallest Box containing all points. BoundingBox returns
+// the zero Box if points is empty.
+func BoundingBox(points []Vec) Box {
+	if len(points) == 0 {
+		return Box{}
+	}
+	bb := Box{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		bb.Min = MinElem(bb.Min, p)
+		bb.Max = MaxElem(bb.Max, p)
+	}
+	return bb
+}
+
 // IsEmpty returns true if a Box's volume is zero
 // or if a Min component is greater than its Max component.
 func (a Box) Empty() bool {
@@ -83,6 +99,46 @@ func (a Box) Vertices() [8]Vec {
 	}
 }
 
+// Octants returns the 8 boxes resulting from splitting the box in half along each axis.
+// Octant i contains vertex i of [Box.Vertices] so ordering of octants matches vertex ordering.
+func (a Box) Octants() [8]Box {
+	center := a.Center()
+	verts := a.Vertices()
+	var octants [8]Box
+	for i, v := range verts {
+		octants[i] = Box{Min: MinElem(center, v), Max: MaxElem(center, v)}
+	}
+	return octants
+}
+
+// Subdivide splits the box into a regular grid of nx*ny*nz cells and returns them.
+// Cells are indexed x-major, y-second-major, same as [AppendGrid]:
+//
+//	cells := box.Subdivide(nx, ny, nz)
+//	ix, iy, iz := 1, 0, 3
+//	cell := cells[iz*nx*ny + iy*nx + ix]
+//
+// Subdivide panics if it receives a non-positive cell count.
+func (a Box) Subdivide(nx, ny, nz int) []Box {
+	if nx <= 0 || ny <= 0 || nz <= 0 {
+		panic("non-positive Subdivide argument")
+	}
+	d := DivElem(a.Size(), Vec{X: float64(nx), Y: float64(ny), Z: float64(nz)})
+	cells := make([]Box, 0, nx*ny*nz)
+	for k := 0; k < nz; k++ {
+		z0 := a.Min.Z + d.Z*float64(k)
+		for j := 0; j < ny; j++ {
+			y0 := a.Min.Y + d.Y*float64(j)
+			for i := 0; i < nx; i++ {
+				x0 := a.Min.X + d.X*float64(i)
+				min := Vec{X: x0, Y: y0, Z: z0}
+				cells = append(cells, Box{Min: min, Max: Add(min, d)})
+			}
+		}
+	}
+	return cells
+}
+
 // Union returns a box enclosing both the receiver and argument Boxes.
 func (a Box) Union(b Box) Box {
 	if a.Empty() {
@@ -147,6 +203,9 @@ func (a Box) Contains(point Vec) bool {
 		a.Min.Z <= point.Z && point.Z <= a.Max.Z
 }
 
+// ContainsBox returns true if argument box is fully contained within receiver box.
+func (a Box) ContainsBox(b Box) bool { return a.Contains(b.Min) && a.Contains(b.Max) }
+
 // Equal returns true if a and b are within tol of eachother for each box limit component.
 func (a Box) Equal(b Box, tol float64) bool {
 	return EqualElem(a.Min, b.Min, tol) && EqualElem(a.Max, b.Max, tol)
@@ -166,3 +225,94 @@ func (a Box) Diagonal() float64 {
 	sz := a.Size()
 	return math.Hypot(math.Hypot(sz.X, sz.Y), sz.Z)
 }
+
+// FaceNormal returns the outward unit normal of the box face perpendicular to
+// axis 0, 1 or 2 (X, Y or Z). positive selects the face at the Max end of the
+// axis, otherwise the face at the Min end is selected. FaceNormal panics if axis is out of bounds.
+func (a Box) FaceNormal(axis int, positive bool) Vec {
+	sign := float64(-1)
+	if positive {
+		sign = 1
+	}
+	switch axis {
+	case 0:
+		return Vec{X: sign}
+	case 1:
+		return Vec{Y: sign}
+	case 2:
+		return Vec{Z: sign}
+	}
+	panic("out of bounds")
+}
+
+// FaceCenter returns the center of the box face selected by axis and positive.
+// See [Box.FaceNormal] for face selection.
+func (a Box) FaceCenter(axis int, positive bool) Vec {
+	center := a.Center()
+	limit := a.Min
+	if positive {
+		limit = a.Max
+	}
+	switch axis {
+	case 0:
+		center.X = limit.X
+	case 1:
+		center.Y = limit.Y
+	case 2:
+		center.Z = limit.Z
+	default:
+		panic("out of bounds")
+	}
+	return center
+}
+
+// Faces returns the planes containing the box's faces with normals pointing
+// outwards, so points within the box have negative signed distance to all planes.
+// Faces are ordered -X, +X, -Y, +Y, -Z, +Z.
+func (a Box) Faces() (faces [6]Plane) {
+	for i := range faces {
+		axis, positive := i/2, i%2 == 1
+		faces[i] = NewPlane(a.FaceCenter(axis, positive), a.FaceNormal(axis, positive))
+	}
+	return faces
+}
+
+// RandomPoint returns a uniformly distributed random point within the box.
+func (a Box) RandomPoint(rng *rand.Rand) Vec {
+	sz := a.Size()
+	return Vec{
+		X: a.Min.X + sz.X*float64(rng.Float64()),
+		Y: a.Min.Y + sz.Y*float64(rng.Float64()),
+		Z: a.Min.Z + sz.Z*float64(rng.Float64()),
+	}
+}
+
+// RandomSurfacePoint returns a uniformly distributed random point on the
+// surface of the box. Faces are chosen with probability proportional to their area.
+func (a Box) RandomSurfacePoint(rng *rand.Rand) Vec {
+	sz := a.Size()
+	areaXY := sz.X * sz.Y
+	areaXZ := sz.X * sz.Z
+	areaYZ := sz.Y * sz.Z
+	p := a.RandomPoint(rng)
+	face := float64(rng.Float64()) * (areaXY + areaXZ + areaYZ)
+	onMax := rng.Intn(2) == 1
+	switch {
+	case face < areaXY:
+		p.Z = a.Min.Z
+		if onMax {
+			p.Z = a.Max.Z
+		}
+	case face < areaXY+areaXZ:
+		p.Y = a.Min.Y
+		if onMax {
+			p.Y = a.Max.Y
+		}
+	default:
+		p.X = a.Min.X
+		if onMax {
+			p.X = a.Max.X
+		}
+	}
+	return p
+}
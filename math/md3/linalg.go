@@ -20,7 +20,15 @@ const (
 
 // SVD performs singular value decomposition on a 3x3 matrix.
 func (a Mat3) SVD() (U, S, V Mat3) {
-	// Extract elements of A
+	b, V := a.svdV()
+	// QR decomposition to compute U and S
+	U, S = b.QRDecomposition()
+	return U, S, V
+}
+
+// svdV returns the right singular vectors V of the SVD of a and B = A*V,
+// whose QR decomposition yields the left singular vectors and singular values.
+func (a Mat3) svdV() (b, V Mat3) {
 	// Normal equations matrix
 	ATA := MulMat3(a.Transpose(), a)
 
@@ -29,14 +37,56 @@ func (a Mat3) SVD() (U, S, V Mat3) {
 
 	// Compute B = A * V
 	V = RotatingMat3(qVr)
-	b := MulMat3(a, V)
+	b = MulMat3(a, V)
 
 	// Sort singular values and adjust V
-	b, V = sortSingularValues(b, V)
+	return sortSingularValues(b, V)
+}
 
-	// QR decomposition to compute U and S
-	U, S = b.QRDecomposition()
-	return U, S, V
+// BatchSVD performs singular value decomposition on each matrix of mats
+// storing the results at the same index of u, s and v. Any of u, s or v may be
+// nil in which case the corresponding result is not computed when possible:
+// if both u and s are nil the QR decomposition step of the SVD is skipped.
+// BatchSVD panics if a non-nil u, s or v is shorter than mats.
+func BatchSVD(mats, u, s, v []Mat3) {
+	if (u != nil && len(u) < len(mats)) || (s != nil && len(s) < len(mats)) || (v != nil && len(v) < len(mats)) {
+		panic("BatchSVD result slice shorter than mats")
+	}
+	needQR := u != nil || s != nil
+	for i := range mats {
+		b, V := mats[i].svdV()
+		if v != nil {
+			v[i] = V
+		}
+		if !needQR {
+			continue
+		}
+		U, S := b.QRDecomposition()
+		if u != nil {
+			u[i] = U
+		}
+		if s != nil {
+			s[i] = S
+		}
+	}
+}
+
+// PolarRotation returns the rotation R of the polar decomposition A = R*P
+// calculated from the SVD of A as U*Vᵀ. R is the rotation closest to A
+// and is commonly used in shape matching and deformation algorithms.
+// Unlike [Mat3.SVD] the singular values are not computed.
+func (a Mat3) PolarRotation() Mat3 {
+	b, V := a.svdV()
+	return MulMat3(b.qrRotation(), V.Transpose())
+}
+
+// BatchPolarRotation stores the [Mat3.PolarRotation] of each matrix
+// of mats at the same index of dst. BatchPolarRotation panics if dst is shorter than mats.
+func BatchPolarRotation(dst, mats []Mat3) {
+	_ = dst[:len(mats)]
+	for i := range mats {
+		dst[i] = mats[i].PolarRotation()
+	}
 }
 
 // QRDecomposition performs QR decomposition of a 3x3 matrix using Mat3 type.
@@ -97,12 +147,37 @@ func (b Mat3) QRDecomposition() (q, r Mat3) {
 		x22: -bs*b23 + as*b33,
 	}
 
-	// Construct cumulative rotation Q = Q1 * Q2 * Q3
+	q = givensRotation(ch1, sh1, ch2, sh2, ch3, sh3)
+	return q, r
+}
+
+// qrRotation returns the orthogonal matrix Q of the [Mat3.QRDecomposition] of b
+// computing only the elements of R needed to find the Givens rotations.
+func (b Mat3) qrRotation() Mat3 {
+	ch1, sh1 := qrGivensQuat(b.x00, b.x10)
+	as := 1 - 2*sh1*sh1
+	bs := 2 * ch1 * sh1
+	r00 := as*b.x00 + bs*b.x10
+	r01 := as*b.x01 + bs*b.x11
+	r11 := -bs*b.x01 + as*b.x11
+
+	ch2, sh2 := qrGivensQuat(r00, b.x20)
+	as = 1 - 2*sh2*sh2
+	bs = 2 * ch2 * sh2
+	b32 := -bs*r01 + as*b.x21
+
+	ch3, sh3 := qrGivensQuat(r11, b32)
+	return givensRotation(ch1, sh1, ch2, sh2, ch3, sh3)
+}
+
+// givensRotation returns the cumulative rotation Q = Q1 * Q2 * Q3 of the
+// three Givens rotations of the QR decomposition.
+func givensRotation(ch1, sh1, ch2, sh2, ch3, sh3 float64) Mat3 {
 	sh12 := sh1 * sh1
 	sh22 := sh2 * sh2
 	sh32 := sh3 * sh3
 
-	q = Mat3{
+	return Mat3{
 		x00: (-1 + 2*sh12) * (-1 + 2*sh22),
 		x01: 4*ch2*ch3*(-1+2*sh12)*sh2*sh3 + 2*ch1*sh1*(-1+2*sh32),
 		x02: 4*ch1*ch3*sh1*sh3 - 2*ch2*(-1+2*sh12)*sh2*(-1+2*sh32),
@@ -115,7 +190,6 @@ func (b Mat3) QRDecomposition() (q, r Mat3) {
 		x21: 2 * ch3 * (1 - 2*sh22) * sh3,
 		x22: (-1 + 2*sh22) * (-1 + 2*sh32),
 	}
-	return q, r
 }
 
 // sortSingularValues sorts the singular values and adjusts V accordingly.
@@ -6,6 +6,8 @@ package md3
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	math "math"
 	ms1 "github.com/soypat/glgl/math/md1"
@@ -36,6 +38,23 @@ func NewMat3(v []float64) (m Mat3) {
 	return m
 }
 
+// Mat3FromCols returns the matrix with columns c0, c1 and c2. When the columns
+// are the basis vectors of a coordinate frame the result transforms vectors from that frame.
+func Mat3FromCols(c0, c1, c2 Vec) Mat3 {
+	return mat3(
+		c0.X, c1.X, c2.X,
+		c0.Y, c1.Y, c2.Y,
+		c0.Z, c1.Z, c2.Z)
+}
+
+// Mat3FromRows returns the matrix with rows r0, r1 and r2.
+func Mat3FromRows(r0, r1, r2 Vec) Mat3 {
+	return mat3(
+		r0.X, r0.Y, r0.Z,
+		r1.X, r1.Y, r1.Z,
+		r2.X, r2.Y, r2.Z)
+}
+
 // IdentityMat3 returns the 3x3 identity matrix.
 func IdentityMat3() Mat3 {
 	return mat3(
@@ -56,17 +75,31 @@ func Skew(v Vec) Mat3 {
 		-v.Y, v.X, 0)
 }
 
-// EqualMat3 tests the equality of 3x3 matrices.
+// EqualMat3 tests the equality of 3x3 matrices to within an absolute tolerance
+// for each element. EqualMat3 returns false if any element of a or b is NaN.
 func EqualMat3(a, b Mat3, tolerance float64) bool {
-	return ms1.EqualWithinAbs(a.x00, b.x00, tolerance) &&
-		ms1.EqualWithinAbs(a.x01, b.x01, tolerance) &&
-		ms1.EqualWithinAbs(a.x02, b.x02, tolerance) &&
-		ms1.EqualWithinAbs(a.x10, b.x10, tolerance) &&
-		ms1.EqualWithinAbs(a.x11, b.x11, tolerance) &&
-		ms1.EqualWithinAbs(a.x12, b.x12, tolerance) &&
-		ms1.EqualWithinAbs(a.x20, b.x20, tolerance) &&
-		ms1.EqualWithinAbs(a.x21, b.x21, tolerance) &&
-		ms1.EqualWithinAbs(a.x22, b.x22, tolerance)
+	aa, ba := a.Array(), b.Array()
+	return equalElems(aa[:], ba[:], tolerance, 0)
+}
+
+// equalElems reports whether elements of a and b are pairwise equal to within absTol
+// or relTol relative to the largest magnitude of the pair. NaN elements are never equal.
+func equalElems(a, b []float64, absTol, relTol float64) bool {
+	for i := range a {
+		if math.IsNaN(a[i]) || math.IsNaN(b[i]) ||
+			!ms1.EqualWithinAbsOrRel(a[i], b[i], absTol, relTol) {
+			return false
+		}
+	}
+	return true
+}
+
+// RotationEqual reports whether the rotation matrices a and b represent the same
+// orientation by checking that a·bᵀ is within tolerance of the identity element-wise.
+// Unlike [EqualMat3] the tolerance bounds the relative rotation between a and b, which for
+// small angles is approximately the angle in radians between the two orientations.
+func RotationEqual(a, b Mat3, tolerance float64) bool {
+	return EqualMat3(MulMat3(a, b.Transpose()), IdentityMat3(), tolerance)
 }
 
 // MulPosition multiplies a V2 position with a rotate/translate matrix.
@@ -247,6 +280,41 @@ func (m Mat3) Array() (rowmajor [9]float64) {
 	return rowmajor
 }
 
+// String returns the matrix formatted as aligned rows. Values near zero are printed as 0.
+func (m Mat3) String() string {
+	a := m.Array()
+	return formatMat(a[:], 3)
+}
+
+// formatMat formats a square row major matrix of order n with right aligned columns.
+func formatMat(rowmajor []float64, n int) string {
+	const snapZero = 1e-6
+	elems := make([]string, len(rowmajor))
+	width := 0
+	for i, v := range rowmajor {
+		if math.Abs(v) < snapZero {
+			v = 0 // Also gets rid of negative zero.
+		}
+		elems[i] = fmt.Sprint(v)
+		width = max(width, len(elems[i]))
+	}
+	var sb strings.Builder
+	for i, e := range elems {
+		if i%n == 0 {
+			if i != 0 {
+				sb.WriteString("]\n")
+			}
+			sb.WriteByte('[')
+		} else {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strings.Repeat(" ", width-len(e)))
+		sb.WriteString(e)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
 // AsMat4 expands the Mat3 to fill the first rows and columns of a Mat4
 // and sets the last diagonal element of the Mat4 to 1.
 func (m Mat3) AsMat4() Mat4 {
@@ -9,6 +9,9 @@
 package md3
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"unsafe"
 
 	math "math"
@@ -42,6 +45,31 @@ const (
 	ZXY
 )
 
+var rotationOrderNames = [...]string{
+	XYX: "XYX", XYZ: "XYZ", XZX: "XZX", XZY: "XZY",
+	YXY: "YXY", YXZ: "YXZ", YZY: "YZY", YZX: "YZX",
+	ZYZ: "ZYZ", ZYX: "ZYX", ZXZ: "ZXZ", ZXY: "ZXY",
+}
+
+// String returns the axes of the rotation order, i.e: "XYZ".
+func (o RotationOrder) String() string {
+	if o < 0 || int(o) >= len(rotationOrderNames) {
+		return "RotationOrder(" + strconv.Itoa(int(o)) + ")"
+	}
+	return rotationOrderNames[o]
+}
+
+// ParseRotationOrder returns the RotationOrder named by s as returned by
+// [RotationOrder.String]. The comparison is case insensitive.
+func ParseRotationOrder(s string) (RotationOrder, error) {
+	for i, name := range rotationOrderNames {
+		if strings.EqualFold(s, name) {
+			return RotationOrder(i), nil
+		}
+	}
+	return 0, errors.New("unknown rotation order " + strconv.Quote(s))
+}
+
 // Quat represents a Quaternion, which is an extension of the imaginary numbers;
 // there's all sorts of interesting theory behind it. In 3D graphics we mostly
 // use it as a cheap way of representing rotation since quaternions are cheaper
@@ -205,6 +233,26 @@ func (q1 Quat) Rotate(v Vec) Vec {
 	return Add(v, x)
 }
 
+// RotateSlice stores in dst the vectors of src rotated by q, same as calling
+// [Quat.Rotate] on each element. The terms of the rotation are computed once
+// so that each vector takes 9 multiplications. dst and src may be the same
+// slice for in-place rotation. RotateSlice panics if dst is shorter than src.
+func (q Quat) RotateSlice(dst, src []Vec) {
+	dst = dst[:len(src)] // Panic early and eliminate bounds checks in loop.
+	w, x, y, z := q.W, q.I, q.J, q.K
+	// Rotate's v + 2w*(q_v x v) + 2q_v x (q_v x v) expanded as a matrix.
+	x00, x01, x02 := 1-2*(y*y+z*z), 2*(x*y-w*z), 2*(x*z+w*y)
+	x10, x11, x12 := 2*(x*y+w*z), 1-2*(x*x+z*z), 2*(y*z-w*x)
+	x20, x21, x22 := 2*(x*z-w*y), 2*(y*z+w*x), 1-2*(x*x+y*y)
+	for i, v := range src {
+		dst[i] = Vec{
+			X: x00*v.X + x01*v.Y + x02*v.Z,
+			Y: x10*v.X + x11*v.Y + x12*v.Z,
+			Z: x20*v.X + x21*v.Y + x22*v.Z,
+		}
+	}
+}
+
 // Mat4 returns the homogeneous 3D rotation matrix corresponding to the
 // quaternion.
 // func (q1 Quat) Mat4() Mat4 {
@@ -265,6 +313,24 @@ func QuatNlerp(q1, q2 Quat, amount float64) Quat {
 	return QuatLerp(q1, q2, amount).Unit()
 }
 
+// RotateTowards rotates q towards target along the shortest path by at most
+// maxRadians. If the angle between q and target is less or equal to maxRadians
+// then target is returned. The result is a unit quaternion.
+func (q Quat) RotateTowards(target Quat, maxRadians float64) Quat {
+	q, target = q.Unit(), target.Unit()
+	dot := q.Dot(target)
+	if dot < 0 {
+		// q and -q represent same orientation, take shortest path.
+		target = target.Scale(-1)
+		dot = -dot
+	}
+	angle := 2 * math.Acos(math.Min(1, dot))
+	if angle <= maxRadians {
+		return target
+	}
+	return QuatSlerp(q, target, maxRadians/angle)
+}
+
 // AnglesToQuat performs a rotation in the specified order. If the order is not
 // a valid RotationOrder, this function will panic
 //
@@ -413,6 +479,10 @@ func QuatLookAt(eye, center, upDir Vec) Quat {
 }
 
 // RotationBetweenVecsQuat calculates the rotation between start and dest.
+//
+// For nearly opposite start and dest (opening angle within ~2.6° of 180°) the rotation
+// axis is fixed to an arbitrary perpendicular axis, so the result jumps discontinuously
+// as dest crosses into that region. Use [RotationBetweenVecsQuatStable] to avoid this.
 func RotationBetweenVecsQuat(start, dest Vec) Quat {
 	// http://www.opengl-tutorial.org/intermediate-tutorials/tutorial-17-quaternions/#I_need_an_equivalent_of_gluLookAt__How_do_I_orient_an_object_towards_a_point__
 	// https://github.com/g-truc/glm/blob/0.9.5/glm/gtx/quaternion.inl#L225
@@ -447,6 +517,57 @@ func RotationBetweenVecsQuat(start, dest Vec) Quat {
 	}
 }
 
+// RotationBetweenVecsQuatStable calculates the shortest arc rotation between start and dest.
+// Unlike [RotationBetweenVecsQuat] the result varies continuously as dest approaches the
+// direction opposite to start. The quaternion is built from the half-way vector h between
+// start and dest as q = (start·h, start×h), which is the normalized form of
+// (1 + start·dest, start×dest) but better conditioned for nearly opposite vectors.
+// Only exactly opposite vectors need an arbitrary rotation axis. The result is a unit quaternion.
+func RotationBetweenVecsQuatStable(start, dest Vec) Quat {
+	start = Unit(start)
+	dest = Unit(dest)
+	half := Add(start, dest)
+	norm := Norm(half)
+	if norm < 1e-6 {
+		// Opposite vectors: rotate half a turn about an axis perpendicular to start.
+		// Crossing with the axis of start's smallest component is well conditioned.
+		axis := Vec{X: 1}
+		abs := AbsElem(start)
+		if abs.Y < abs.X && abs.Y <= abs.Z {
+			axis = Vec{Y: 1}
+		} else if abs.Z < abs.X && abs.Z < abs.Y {
+			axis = Vec{Z: 1}
+		}
+		return Quat{}.WithIJK(Unit(Cross(start, axis)))
+	}
+	// With h = half/norm: start·h = norm/2 and start×h = start×dest/norm.
+	// Computing the real part from norm avoids cancellation in start·h.
+	q := Quat{W: 0.5 * norm}.WithIJK(Scale(1/norm, Cross(start, dest)))
+	return q.Unit()
+}
+
+// rotationMat3ToQuat returns the unit quaternion of the pure rotation matrix r.
+// It is the inverse of [RotatingMat3].
+func rotationMat3ToQuat(r Mat3) Quat {
+	// Shepperd's method: divide by the largest of the quaternion's components for stability.
+	var q Quat
+	switch trace := r.x00 + r.x11 + r.x22; {
+	case trace > 0:
+		s := 2 * math.Sqrt(trace+1)
+		q = Quat{W: s / 4, I: (r.x21 - r.x12) / s, J: (r.x02 - r.x20) / s, K: (r.x10 - r.x01) / s}
+	case r.x00 > r.x11 && r.x00 > r.x22:
+		s := 2 * math.Sqrt(1+r.x00-r.x11-r.x22)
+		q = Quat{W: (r.x21 - r.x12) / s, I: s / 4, J: (r.x01 + r.x10) / s, K: (r.x02 + r.x20) / s}
+	case r.x11 > r.x22:
+		s := 2 * math.Sqrt(1+r.x11-r.x00-r.x22)
+		q = Quat{W: (r.x02 - r.x20) / s, I: (r.x01 + r.x10) / s, J: s / 4, K: (r.x12 + r.x21) / s}
+	default:
+		s := 2 * math.Sqrt(1+r.x22-r.x00-r.x11)
+		q = Quat{W: (r.x10 - r.x01) / s, I: (r.x02 + r.x20) / s, J: (r.x12 + r.x21) / s, K: s / 4}
+	}
+	return q.Unit()
+}
+
 // RotationMat3 returns a rotation 3x3 matrix.
 func (q Quat) RotationMat3() Mat3 {
 	qv := q.IJK()
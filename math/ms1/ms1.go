@@ -32,11 +32,104 @@ func SmoothStep(edge0, edge1, x float32) float32 {
 	return t * t * (3 - 2*t)
 }
 
+// InterpClamped is like [Interp] but clamps a to the interval [0,1] so the
+// result never extrapolates beyond x or y.
+func InterpClamped(x, y, a float32) float32 {
+	return Interp(x, y, Clamp(a, 0, 1))
+}
+
+// Damp moves current towards target with exponential decay of rate smoothing over
+// a time step dt. Damp is frame rate independent: two steps of dt/2 give the same
+// result as a single step of dt, which makes it suitable for smoothing camera motion.
+// Larger smoothing values converge faster.
+func Damp(current, target, smoothing, dt float32) float32 {
+	return target + (current-target)*math.Exp(-smoothing*dt)
+}
+
+// Wrap returns x wrapped into the half-open interval [Min, Max) such that the result
+// differs from x by an integer multiple of Max-Min. Wrap is useful for cyclic parameters.
+func Wrap(x, Min, Max float32) float32 {
+	period := Max - Min
+	r := math.Mod(x-Min, period)
+	if r < 0 {
+		r += period
+	}
+	if r >= period {
+		r = 0 // Can happen due to rounding when adding period to tiny negative r.
+	}
+	return Min + r
+}
+
+// PingPong returns a triangle wave of x which goes back and forth between 0 and
+// length, such that PingPong(0)=0, PingPong(length)=length and PingPong(2*length)=0.
+func PingPong(x, length float32) float32 {
+	t := Wrap(x, 0, 2*length)
+	return length - math.Abs(t-length)
+}
+
+// WrapAngle returns the equivalent of the angle in radians in the interval (-π, π].
+func WrapAngle(radians float32) float32 {
+	a := Wrap(radians, -math.Pi, math.Pi)
+	if a == -math.Pi {
+		return math.Pi
+	}
+	return a
+}
+
+// FastInvSqrt returns a fast approximation of 1/sqrt(x) for positive x
+// with a relative error below 0.2%. Use [FastInvSqrt1] for better accuracy.
+func FastInvSqrt(x float32) float32 {
+	return internal.FastInvSqrtfloat32(x, 1)
+}
+
+// FastInvSqrt1 returns a fast approximation of 1/sqrt(x) for positive x
+// with a relative error below 5e-6. It is slightly slower than [FastInvSqrt].
+func FastInvSqrt1(x float32) float32 {
+	return internal.FastInvSqrtfloat32(x, 2)
+}
+
+// Hash returns the PCG integer hash of seed. It matches the following GLSL
+// function bit for bit so CPU and GPU procedural content can agree:
+//
+//	uint hash(uint seed) {
+//		uint state = seed * 747796405u + 2891336453u;
+//		uint word = ((state >> ((state >> 28u) + 4u)) ^ state) * 277803737u;
+//		return (word >> 22u) ^ word;
+//	}
+func Hash(seed uint32) uint32 {
+	state := seed*747796405 + 2891336453
+	word := ((state >> ((state >> 28) + 4)) ^ state) * 277803737
+	return (word >> 22) ^ word
+}
+
+// HashToFloat returns a pseudo random number in [0,1) derived from [Hash] of seed.
+// It matches the following GLSL function exactly:
+//
+//	float hashToFloat(uint seed) {
+//		return float(hash(seed) >> 8u) * (1.0 / 16777216.0);
+//	}
+func HashToFloat(seed uint32) float32 {
+	return float32(Hash(seed)>>8) * (1.0 / 16777216.0)
+}
+
 // EqualWithinAbs checks if a and b are within tol of eachother.
 func EqualWithinAbs(a, b, tol float32) bool {
 	return math.Abs(a-b) <= tol
 }
 
+// EqualWithinRel checks if a and b are within relTol of eachother relative to
+// the largest magnitude of the two: |a-b| <= relTol*max(|a|,|b|).
+func EqualWithinRel(a, b, relTol float32) bool {
+	return math.Abs(a-b) <= relTol*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// EqualWithinAbsOrRel checks if a and b are within absTol of eachother or within
+// relTol relative to the largest magnitude of the two. The absolute tolerance
+// is useful when comparing values near zero where the relative tolerance is too strict.
+func EqualWithinAbsOrRel(a, b, absTol, relTol float32) bool {
+	return EqualWithinAbs(a, b, absTol) || EqualWithinRel(a, b, relTol)
+}
+
 // DefaultNewtonRaphsonSolver returns a [NewtonRaphsonSolver] with recommended parameters.
 func DefaultNewtonRaphsonSolver() NewtonRaphsonSolver {
 	return NewtonRaphsonSolver{
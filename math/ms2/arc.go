@@ -0,0 +1,52 @@
+package ms2
+
+import (
+	"errors"
+
+	math "github.com/chewxy/math32"
+)
+
+// Arc is a circular arc of absolute radius |Radius| joining Start and End.
+// A positive Radius specifies a counter-clockwise arc, a negative Radius specifies
+// a clockwise arc, same as [PolygonControlPoint.Arc]. The arc is the shorter of
+// the two arcs of the circle joining Start and End so it spans at most half a circle.
+type Arc struct {
+	Start, End Vec
+	Radius     float32
+}
+
+// CenterAngle returns the center of the arc's circle and the signed opening
+// angle of the arc as seen from the center. The angle is positive for
+// counter-clockwise arcs. An error is returned if |Radius| is too small for
+// the arc to join Start and End.
+func (a Arc) CenterAngle() (center Vec, angle float32, err error) {
+	if a.Radius == 0 {
+		return Vec{}, 0, errSmallArcRadius
+	}
+	return arcCenterFrom2points(a.Start, a.End, a.Radius)
+}
+
+// Length returns the length of the arc. Length returns NaN
+// if |Radius| is too small for the arc to join Start and End.
+func (a Arc) Length() float32 {
+	_, angle, err := a.CenterAngle()
+	if err != nil {
+		return math.NaN()
+	}
+	return math.Abs(angle * a.Radius)
+}
+
+// AppendPolyline discretises the arc in facets segments and appends the
+// facets+1 resulting points, Start and End included, to dst.
+func (a Arc) AppendPolyline(dst []Vec, facets int) ([]Vec, error) {
+	if facets <= 0 {
+		return dst, errors.New("non-positive arc facets")
+	}
+	center, angle, err := a.CenterAngle()
+	if err != nil {
+		return dst, err
+	}
+	dst = append(dst, a.Start)
+	dst = appendArcWithCenter(dst, a.Start, center, angle, int32(facets))
+	return append(dst, a.End), nil
+}
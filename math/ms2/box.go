@@ -27,6 +27,20 @@ func NewCenteredBox(center, size Vec) Box {
 	return Box{Min: Sub(center, half), Max: Add(center, half)}
 }
 
+// BoundingBox returns the smallest Box containing all points. BoundingBox returns
+// the zero Box if points is empty.
+func BoundingBox(points []Vec) Box {
+	if len(points) == 0 {
+		return Box{}
+	}
+	bb := Box{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		bb.Min = MinElem(bb.Min, p)
+		bb.Max = MaxElem(bb.Max, p)
+	}
+	return bb
+}
+
 // IsEmpty returns true if a Box's volume is zero
 // or if a Min component is greater than its Max component.
 func (a Box) Empty() bool {
@@ -70,6 +84,43 @@ func (a Box) Vertices() [4]Vec {
 	}
 }
 
+// Quadrants returns the 4 boxes resulting from splitting the box in half along each axis.
+// Quadrant i contains vertex i of [Box.Vertices] so ordering of quadrants matches vertex ordering.
+func (a Box) Quadrants() [4]Box {
+	center := a.Center()
+	verts := a.Vertices()
+	var quadrants [4]Box
+	for i, v := range verts {
+		quadrants[i] = Box{Min: MinElem(center, v), Max: MaxElem(center, v)}
+	}
+	return quadrants
+}
+
+// Subdivide splits the box into a regular grid of nx*ny cells and returns them.
+// Cells are indexed x-major, same as [AppendGrid]:
+//
+//	cells := box.Subdivide(nx, ny)
+//	ix, iy := 1, 0
+//	cell := cells[iy*nx + ix]
+//
+// Subdivide panics if it receives a non-positive cell count.
+func (a Box) Subdivide(nx, ny int) []Box {
+	if nx <= 0 || ny <= 0 {
+		panic("non-positive Subdivide argument")
+	}
+	d := DivElem(a.Size(), Vec{X: float32(nx), Y: float32(ny)})
+	cells := make([]Box, 0, nx*ny)
+	for j := 0; j < ny; j++ {
+		y0 := a.Min.Y + d.Y*float32(j)
+		for i := 0; i < nx; i++ {
+			x0 := a.Min.X + d.X*float32(i)
+			min := Vec{X: x0, Y: y0}
+			cells = append(cells, Box{Min: min, Max: Add(min, d)})
+		}
+	}
+	return cells
+}
+
 // Union returns a box enclosing both the receiver and argument Boxes.
 func (a Box) Union(b Box) Box {
 	if a.Empty() {
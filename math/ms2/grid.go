@@ -63,6 +63,47 @@ func GridSubdomain(domain Box, nxDomain, nyDomain int, subdomain Box) (iStart, n
 	return iStart, nxSub, nySub
 }
 
+// GridIndices returns the indices that stitch a grid of nx by ny vertices generated
+// by [AppendGrid] into triangles to be drawn with GL_TRIANGLES. Two triangles are
+// generated per grid cell, both with counter-clockwise winding when y points up.
+// GridIndices panics if it receives a dimension less than 2.
+func GridIndices(nx, ny int) []uint32 {
+	if nx <= 1 || ny <= 1 {
+		panic("GridIndices needs more grid subdivisions")
+	}
+	indices := make([]uint32, 0, 6*(nx-1)*(ny-1))
+	for j := 0; j < ny-1; j++ {
+		for i := 0; i < nx-1; i++ {
+			v0 := uint32(j*nx + i) // Bottom left vertex of cell.
+			v1 := v0 + 1
+			v2 := v0 + uint32(nx)
+			v3 := v2 + 1
+			indices = append(indices, v0, v1, v3, v0, v3, v2)
+		}
+	}
+	return indices
+}
+
+// GridStripIndices is like [GridIndices] but returns indices for drawing the grid
+// as a single GL_TRIANGLE_STRIP. Rows of the grid are joined by degenerate triangles
+// which are not rasterized.
+func GridStripIndices(nx, ny int) []uint32 {
+	if nx <= 1 || ny <= 1 {
+		panic("GridStripIndices needs more grid subdivisions")
+	}
+	indices := make([]uint32, 0, 2*nx*(ny-1)+2*(ny-2))
+	for j := 0; j < ny-1; j++ {
+		if j > 0 {
+			// Degenerate triangles: repeat last vertex of previous row and first of this row.
+			indices = append(indices, indices[len(indices)-1], uint32((j+1)*nx))
+		}
+		for i := 0; i < nx; i++ {
+			indices = append(indices, uint32((j+1)*nx+i), uint32(j*nx+i))
+		}
+	}
+	return indices
+}
+
 func iceil(f float32) int {
 	return int(math.Ceil(f))
 }
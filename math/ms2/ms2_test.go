@@ -3,6 +3,8 @@ package ms2
 import (
 	"math/rand"
 	"testing"
+
+	"github.com/soypat/glgl/math/ms3"
 )
 
 func TestGridSubdomain(t *testing.T) {
@@ -99,3 +101,240 @@ func randSubBox(domain Box, rng *rand.Rand) (sub Box) {
 // if ms1.EqualWithinAbs(domain.Max.Y, subdomain.Max.Y, tol) || domain.Max.Y == subdomain.Max.Y {
 // 	subdomain.Max.Y += 1e-3 * subsz.Y
 // }
+
+func TestFlattenVecs(t *testing.T) {
+	vs := []Vec{{X: 1, Y: 2}, {X: -3, Y: 4}, {X: 5, Y: -6}}
+	flat := FlattenVecs(nil, vs)
+	want := []float32{1, 2, -3, 4, 5, -6}
+	if len(flat) != len(want) {
+		t.Fatalf("want %d floats, got %d", len(want), len(flat))
+	}
+	for i := range want {
+		if flat[i] != want[i] {
+			t.Errorf("want %v, got %v", want, flat)
+			break
+		}
+	}
+	// Appending keeps existing contents of dst.
+	flat = FlattenVecs(flat[:2], vs[2:])
+	if len(flat) != 4 || flat[0] != 1 || flat[1] != 2 || flat[2] != 5 || flat[3] != -6 {
+		t.Errorf("want [1 2 5 -6] after append, got %v", flat)
+	}
+	got := AppendVecsFromFloats(nil, want)
+	if len(got) != len(vs) || got[0] != vs[0] || got[1] != vs[1] || got[2] != vs[2] {
+		t.Errorf("round trip want %v, got %v", vs, got)
+	}
+}
+
+func TestSampleMeshSurface(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tris := []Triangle{
+		{{X: 0}, {X: 1}, {Y: 2}},          // Area 1.
+		{{X: 10}, {X: 13}, {X: 10, Y: 2}}, // Area 3.
+		{{X: 20}, {X: 21}, {X: 22}},       // Degenerate, area 0.
+	}
+	const n = 4000
+	points := SampleMeshSurface(tris, n, rng)
+	if len(points) != n {
+		t.Fatalf("want %d points, got %d", n, len(points))
+	}
+	inFirst := 0
+	for _, p := range points {
+		var u float32
+		switch {
+		case p.X < 5:
+			inFirst++
+			u = p.X + p.Y/2
+		case p.X < 15:
+			u = (p.X-10)/3 + p.Y/2
+		default:
+			t.Fatalf("point %v sampled from degenerate triangle", p)
+		}
+		if p.X < 0 || p.Y < 0 || u > 1+1e-6 {
+			t.Fatalf("point %v outside of triangles", p)
+		}
+	}
+	if inFirst < n/4*8/10 || inFirst > n/4*12/10 {
+		t.Errorf("first triangle sampled %d times, expected around %d", inFirst, n/4)
+	}
+	if SampleMeshSurface(tris[2:], n, rng) != nil {
+		t.Error("expected nil points for zero area mesh")
+	}
+	if SampleMeshSurface(tris, -1, rng) != nil {
+		t.Error("expected nil points for negative n")
+	}
+}
+
+func TestBoxQuadrants(t *testing.T) {
+	const tol = 1e-5
+	box := NewBox(-1, -2, 3, 4)
+	quadrants := box.Quadrants()
+	verts := box.Vertices()
+	var union Box
+	var area float32
+	for i, q := range quadrants {
+		area += q.Area()
+		union = union.Union(q)
+		if !EqualElem(q.Size(), Scale(0.5, box.Size()), tol) {
+			t.Errorf("quadrant %d has wrong size %v", i, q.Size())
+		}
+		if !q.Contains(verts[i]) || !box.ContainsBox(q) {
+			t.Errorf("quadrant %d does not contain vertex %d or is not contained in box", i, i)
+		}
+		for j := i + 1; j < len(quadrants); j++ {
+			if overlap := q.Intersect(quadrants[j]).Area(); overlap > tol {
+				t.Errorf("quadrants %d and %d overlap with area %v", i, j, overlap)
+			}
+		}
+	}
+	// Quadrants cover the box without overlap so their areas add up to the box's.
+	if !union.Equal(box, tol) {
+		t.Errorf("quadrant union %v does not match box %v", union, box)
+	}
+	if d := area - box.Area(); d > tol || d < -tol {
+		t.Errorf("quadrant area sum %v does not match box area %v", area, box.Area())
+	}
+}
+
+func TestBoxSubdivide(t *testing.T) {
+	const tol = 1e-5
+	const nx, ny = 3, 4
+	box := Box{Min: Vec{X: -1, Y: 2}, Max: Vec{X: 5, Y: 10}}
+	cells := box.Subdivide(nx, ny)
+	if len(cells) != nx*ny {
+		t.Fatalf("want %d cells, got %d", nx*ny, len(cells))
+	}
+	grid := AppendGrid(nil, box, nx+1, ny+1)
+	for iy := 0; iy < ny; iy++ {
+		for ix := 0; ix < nx; ix++ {
+			cell := cells[iy*nx+ix]
+			wantMin := grid[iy*(nx+1)+ix]
+			wantMax := grid[(iy+1)*(nx+1)+ix+1]
+			if !EqualElem(cell.Min, wantMin, tol) || !EqualElem(cell.Max, wantMax, tol) {
+				t.Errorf("cell (%d,%d) want %v-%v, got %v", ix, iy, wantMin, wantMax, cell)
+			}
+		}
+	}
+	var union Box
+	var area float32
+	for _, cell := range cells {
+		union = union.Union(cell)
+		area += cell.Area()
+	}
+	if !union.Equal(box, tol) {
+		t.Errorf("cell union %v does not match box %v", union, box)
+	}
+	if d := area - box.Area(); d > tol*box.Area() || d < -tol*box.Area() {
+		t.Errorf("cell area sum %v does not match box area %v", area, box.Area())
+	}
+}
+
+func TestDistance(t *testing.T) {
+	p, q := Vec{X: 1, Y: 2}, Vec{X: 4, Y: 6}
+	if got := Distance(p, q); got != 5 {
+		t.Errorf("want distance 5, got %v", got)
+	}
+	if got := DistanceSquared(p, q); got != 25 {
+		t.Errorf("want squared distance 25, got %v", got)
+	}
+}
+
+func TestTransform2D(t *testing.T) {
+	const tol = 1e-5
+	parent := Transform2D{Position: Vec{X: 1, Y: 2}, Rotation: 0.7, Scale: Vec{X: 2, Y: 2}}
+	child := Transform2D{Position: Vec{X: -1, Y: 0.5}, Rotation: -0.3, Scale: Vec{X: 1, Y: 3}}
+	p := Vec{X: 0.5, Y: -2}
+	got := parent.TransformPoint(p)
+	hom := ms3.MulMatVec(parent.Matrix(), ms3.Vec{X: p.X, Y: p.Y, Z: 1})
+	if want := (Vec{X: hom.X, Y: hom.Y}); !EqualElem(got, want, tol) || hom.Z != 1 {
+		t.Errorf("TransformPoint want %v, got %v", want, got)
+	}
+	if back := parent.Inverse().TransformPoint(got); !EqualElem(back, p, tol) {
+		t.Errorf("Inverse want %v, got %v", p, back)
+	}
+	got = parent.Mul(child).TransformPoint(p)
+	want := parent.TransformPoint(child.TransformPoint(p))
+	if !EqualElem(got, want, tol) {
+		t.Errorf("Mul want %v, got %v", want, got)
+	}
+	if got := IdentityTransform2D().TransformPoint(p); got != p {
+		t.Errorf("identity transform changed point %v to %v", p, got)
+	}
+}
+
+func TestHash(t *testing.T) {
+	const want = 2112726488 // hash(1 + hash(2))
+	if got := Hash(1, 2); got != want {
+		t.Errorf("want hash %d, got %d", uint32(want), got)
+	}
+	if f := HashToFloat(1, 2); f < 0 || f >= 1 {
+		t.Errorf("HashToFloat out of [0,1): %v", f)
+	}
+}
+
+func TestMulBox(t *testing.T) {
+	const tol = 1e-5
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 32; i++ {
+		m := RotationMat2(float32(rng.Float64()) * 6.3)
+		offset := Vec{X: float32(rng.NormFloat64()), Y: float32(rng.NormFloat64())}
+		b := NewBox(-1, -2, float32(rng.Float64()), 3)
+		// Reference: transform vertices and refit.
+		verts := b.Vertices()
+		first := Add(MulMatVec(m, verts[0]), offset)
+		want := Box{Min: first, Max: first}
+		for _, v := range verts[1:] {
+			want = want.IncludePoint(Add(MulMatVec(m, v), offset))
+		}
+		got := MulBoxAffine(m, offset, b)
+		if !got.Equal(want, tol) {
+			t.Errorf("MulBoxAffine want %v, got %v", want, got)
+		}
+		got = m.MulBox(b).Add(offset)
+		if !got.Equal(want, tol) {
+			t.Errorf("MulBox want %v, got %v", want, got)
+		}
+	}
+}
+
+func TestGridIndices(t *testing.T) {
+	const nx, ny = 4, 3
+	grid := AppendGrid(nil, Box{Max: Vec{X: 3, Y: 2}}, nx, ny)
+	area := func(a, b, c uint32) float32 {
+		return Cross(Sub(grid[b], grid[a]), Sub(grid[c], grid[a])) / 2
+	}
+	indices := GridIndices(nx, ny)
+	if len(indices) != 6*(nx-1)*(ny-1) {
+		t.Fatalf("want %d indices, got %d", 6*(nx-1)*(ny-1), len(indices))
+	}
+	var total float32
+	for i := 0; i < len(indices); i += 3 {
+		a := area(indices[i], indices[i+1], indices[i+2])
+		if a <= 0 {
+			t.Errorf("triangle %d not counter-clockwise", i/3)
+		}
+		total += a
+	}
+	if total != 6 {
+		t.Errorf("want total triangle area 6, got %v", total)
+	}
+	strip := GridStripIndices(nx, ny)
+	total = 0
+	for i := 2; i < len(strip); i++ {
+		a, b, c := strip[i-2], strip[i-1], strip[i]
+		if a == b || b == c || a == c {
+			continue // Degenerate.
+		}
+		if i%2 == 1 {
+			a, b = b, a // Odd triangles of a strip have their winding flipped.
+		}
+		ar := area(a, b, c)
+		if ar <= 0 {
+			t.Errorf("strip triangle %d not counter-clockwise", i-2)
+		}
+		total += ar
+	}
+	if total != 6 {
+		t.Errorf("want total strip area 6, got %v", total)
+	}
+}
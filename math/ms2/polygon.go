@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	math "github.com/chewxy/math32"
+	"github.com/soypat/glgl/math/ms1"
 )
 
 type cpAtIdxErr struct {
@@ -30,7 +31,7 @@ type PolygonBuilder struct {
 // so that the user may control the polygon's shape. By default represents a vertex joining two other neighboring vertices.
 type PolygonControlPoint struct {
 	v      Vec     // Absolute vertex position.
-	radius float32 // Smoothing radius, if zero then no smoothing.
+	radius float32 // Smoothing radius, if zero then no smoothing. If negative and facets positive it is the smoothing's leg length.
 	facets int32   // Amount of facets to create when smoothing. If negative indicates arcing instead of smoothing.
 }
 
@@ -113,6 +114,39 @@ func (p *PolygonBuilder) IsClockwise() bool {
 	return windingSum < 0
 }
 
+// EnsureOrientation reverses the order of the control points if needed so that
+// [PolygonBuilder.IsClockwise] matches clockwise. Corner smoothing stays with its vertex
+// while arcs are moved to the other end of their edge with the radius sign flipped
+// so that the built polygon traces the same outline. Smoothing of a vertex where an
+// arc starts is dropped since the vertex becomes the arc's end after the reversal.
+// EnsureOrientation does nothing if the polygon has less than 3 vertices.
+func (p *PolygonBuilder) EnsureOrientation(clockwise bool) {
+	n := len(p.verts)
+	if n < 3 || p.IsClockwise() == clockwise {
+		return
+	}
+	// Arc metadata describes the edge ending at its control point. Reversed, that
+	// edge ends at the previous control point so metadata is shifted before reversing.
+	first := p.verts[0]
+	for i := range p.verts {
+		current := p.verts[i]
+		next := first
+		if i+1 < n {
+			next = p.verts[i+1]
+		}
+		switch {
+		case next.isArc():
+			current.radius, current.facets = -next.radius, next.facets
+		case current.isArc():
+			current.radius, current.facets = 0, 0
+		}
+		p.verts[i] = current
+	}
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		p.verts[i], p.verts[j] = p.verts[j], p.verts[i]
+	}
+}
+
 // AppendVecs appends the Polygon's discretized representation to the argument Vec buffer and returns the result.
 // It does not change the internal state of the PolygonBuilder and thus can be called repeatedly.
 func (p *PolygonBuilder) AppendVecs(buf []Vec) ([]Vec, error) {
@@ -132,6 +166,16 @@ func (p *PolygonBuilder) AppendVecs(buf []Vec) ([]Vec, error) {
 		} else if current.isSmoothed() {
 			next := p.verts[(i+1)%len(p.verts)]
 			buf, err = appendSmoothedCorner(buf, prev.v, current.v, next.v, current.radius, current.facets)
+		} else if current.isFilletLeg() && current.facets == 1 {
+			next := p.verts[(i+1)%len(p.verts)]
+			buf, err = appendChamferLeg(buf, prev.v, current.v, next.v, -current.radius)
+		} else if current.isFilletLeg() {
+			next := p.verts[(i+1)%len(p.verts)]
+			var r float32
+			r, err = filletRadiusFromLeg(prev.v, current.v, next.v, -current.radius)
+			if err == nil {
+				buf, err = appendSmoothedCorner(buf, prev.v, current.v, next.v, r, current.facets)
+			}
 		} else {
 			buf = append(buf, current.v)
 		}
@@ -143,6 +187,47 @@ func (p *PolygonBuilder) AppendVecs(buf []Vec) ([]Vec, error) {
 	return buf, nil
 }
 
+// SignedDistancePolygon returns the Euclidean distance from p to the closest edge of
+// the closed polygon outline formed by verts, such as the result of [PolygonBuilder.AppendVecs].
+// The distance is negative if p is inside the polygon as determined by its winding number,
+// so the result is independent of the vertex ordering. Points on an edge have distance 0.
+func SignedDistancePolygon(p Vec, verts []Vec) float32 {
+	if len(verts) == 0 {
+		return math.Inf(1)
+	}
+	minDist2 := Norm2(Sub(p, verts[0]))
+	winding := 0
+	vPrev := verts[len(verts)-1]
+	for _, v := range verts {
+		edge := Sub(v, vPrev)
+		w := Sub(p, vPrev)
+		edgeLen2 := Norm2(edge)
+		var tEdge float32
+		if edgeLen2 > 0 {
+			tEdge = ms1.Clamp(Dot(w, edge)/edgeLen2, 0, 1)
+		}
+		minDist2 = math.Min(minDist2, Norm2(Sub(w, Scale(tEdge, edge))))
+		// Winding number crossing test. Positive cross means p is left of edge.
+		cross := edge.X*w.Y - edge.Y*w.X
+		if vPrev.Y <= p.Y {
+			if v.Y > p.Y && cross > 0 {
+				winding++
+			}
+		} else if v.Y <= p.Y && cross < 0 {
+			winding--
+		}
+		vPrev = v
+	}
+	if minDist2 == 0 {
+		return 0
+	}
+	dist := math.Sqrt(minDist2)
+	if winding != 0 {
+		return -dist
+	}
+	return dist
+}
+
 func (p *PolygonBuilder) last() *PolygonControlPoint {
 	if len(p.verts) > 0 {
 		return &p.verts[len(p.verts)-1]
@@ -169,8 +254,26 @@ func (v *PolygonControlPoint) Arc(radius float32, facets int) {
 		v.facets = -int32(facets)
 	}
 }
-func (v *PolygonControlPoint) isSmoothed() bool { return v.facets > 0 && v.radius > 0 }
-func (v *PolygonControlPoint) isArc() bool      { return v.facets < 0 && v.radius != 0 }
+
+// FilletLeg smoothes this polygon vertex with an arc discretised in facets which
+// starts and ends legLength away from the vertex along its two edges. The radius of
+// the arc is calculated from the corner's opening angle when the polygon is built.
+func (v *PolygonControlPoint) FilletLeg(legLength float32, facets int) {
+	if legLength > 0 && facets > 0 {
+		v.radius = -legLength
+		v.facets = int32(facets)
+	}
+}
+
+// ChamferLeg cuts this polygon vertex with a single facet which
+// starts and ends legLength away from the vertex along its two edges.
+func (v *PolygonControlPoint) ChamferLeg(legLength float32) {
+	v.FilletLeg(legLength, 1)
+}
+
+func (v *PolygonControlPoint) isSmoothed() bool  { return v.facets > 0 && v.radius > 0 }
+func (v *PolygonControlPoint) isFilletLeg() bool { return v.facets > 0 && v.radius < 0 }
+func (v *PolygonControlPoint) isArc() bool       { return v.facets < 0 && v.radius != 0 }
 
 const sqrtHalf = math.Sqrt2 / 2
 
@@ -237,7 +340,47 @@ func arcCenterFrom2points(p1, p2 Vec, r float32) (Vec, float32, error) {
 	return Add(chordCenter, perp), math.Copysign(2*chordThetaDiv2, r), nil
 }
 
+// filletRadiusFromLeg returns the radius of the arc tangent to the edges
+// p1->p0 and p1->p2 at a distance leg from the corner p1.
+func filletRadiusFromLeg(p0, p1, p2 Vec, leg float32) (float32, error) {
+	V10 := Sub(p0, p1)
+	norm10 := Norm(V10)
+	V12 := Sub(p2, p1)
+	norm12 := Norm(V12)
+	if leg-norm10 > arcTol || leg-norm12 > arcTol {
+		return 0, errLargeFilletLeg
+	} else if norm10 == 0 || norm12 == 0 {
+		return 0, errBadSmooth
+	}
+	// Ill conditioned opening angles are rejected by appendSmoothedCorner.
+	theta := math.Acos(ms1.Clamp(Dot(V10, V12)/(norm10*norm12), -1, 1))
+	// Leg length d of a smoothing of radius r is d = r/tan(theta/2).
+	return leg * math.Tan(0.5*theta), nil
+}
+
+// appendChamferLeg appends the vertices of a single facet cut of the corner p1
+// which lie leg away from p1 along its edges to p0 and p2.
+func appendChamferLeg(dst []Vec, p0, p1, p2 Vec, leg float32) ([]Vec, error) {
+	if _, err := filletRadiusFromLeg(p0, p1, p2, leg); err != nil {
+		return dst, err
+	}
+	V10 := Sub(p0, p1)
+	norm10 := Norm(V10)
+	V12 := Sub(p2, p1)
+	norm12 := Norm(V12)
+	start := Add(p1, Scale(leg/norm10, V10))
+	end := Add(p1, Scale(leg/norm12, V12))
+	if !EqualElem(p0, start, arcTol*norm10) {
+		dst = append(dst, start) // Cap chamfer if p0 point not near leg start.
+	}
+	if !EqualElem(p2, end, arcTol*norm12) {
+		dst = append(dst, end) // Cap chamfer if p2 point not near leg end.
+	}
+	return dst, nil
+}
+
 var (
+	errLargeFilletLeg    = errors.New("fillet leg length too large")
 	errLargeSmoothRadius = errors.New("smoothing radius too large")
 	errSmallArcRadius    = errors.New("arc radius too small")
 	errBadSmooth         = errors.New("badly conditioned smoothing")
@@ -247,7 +390,7 @@ var (
 func appendSmoothedCorner(dst []Vec, p0, p1, p2 Vec, r float32, facets int32) ([]Vec, error) {
 
 	if facets <= 1 {
-		return dst, nil // Chamfer case facets==1.
+		return dst, nil // Nothing to do.
 	}
 	// Calculate midpoint between two control points.
 	// The arc center of corner will lie in direction of this midpoint from corner point p1.
@@ -133,3 +133,197 @@ func TestPolygon_IsClockwise(t *testing.T) {
 		}
 	}
 }
+
+func TestPolygon_EnsureOrientation(t *testing.T) {
+	const tol = 1e-4
+	var poly PolygonBuilder
+	poly.AddXY(0, 0).Smooth(0.5, 4)
+	poly.AddXY(2, 0)
+	poly.AddXY(2, 2).Arc(-1.5, 5)
+	poly.AddXY(0, 2).ChamferLeg(0.3)
+	cw := poly.IsClockwise()
+	want, err := poly.AppendVecs(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	poly.EnsureOrientation(cw)
+	got, err := poly.AppendVecs(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("orientation already satisfied, want unchanged polygon")
+	}
+	poly.EnsureOrientation(!cw)
+	if poly.IsClockwise() == cw {
+		t.Fatalf("want IsClockwise=%v after reversal", !cw)
+	}
+	got, err = poly.AppendVecs(got[:0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want %d vertices, got %d", len(want), len(got))
+	}
+	for _, w := range want {
+		found := false
+		for _, g := range got {
+			if EqualElem(g, w, tol) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("vertex %v missing from reversed polygon %v", w, got)
+		}
+	}
+	poly.EnsureOrientation(cw)
+	if poly.IsClockwise() != cw {
+		t.Fatalf("want IsClockwise=%v after second reversal", cw)
+	}
+	got, err = poly.AppendVecs(got[:0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if !EqualElem(got[i], want[i], tol) {
+			t.Fatalf("double reversal changed polygon: want %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSignedDistancePolygon(t *testing.T) {
+	const tol = 1e-6
+	square := []Vec{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}}
+	reversed := []Vec{square[3], square[2], square[1], square[0]}
+	for _, test := range []struct {
+		p    Vec
+		want float32
+	}{
+		{p: Vec{X: 1, Y: 1}, want: -1},
+		{p: Vec{X: 0.5, Y: 1}, want: -0.5},
+		{p: Vec{X: 3, Y: 1}, want: 1},
+		{p: Vec{X: 3, Y: 3}, want: math.Sqrt2},
+		{p: Vec{X: 1, Y: 0}, want: 0},
+		{p: Vec{X: 2, Y: 2}, want: 0},
+	} {
+		for _, verts := range [][]Vec{square, reversed} {
+			got := SignedDistancePolygon(test.p, verts)
+			if math.Abs(got-test.want) > tol {
+				t.Errorf("SignedDistancePolygon(%v) want %v, got %v", test.p, test.want, got)
+			}
+		}
+	}
+}
+
+func TestArc(t *testing.T) {
+	const tol = 1e-5
+	for _, test := range []struct {
+		arc        Arc
+		wantCenter Vec
+		wantAngle  float32
+	}{
+		{arc: Arc{Start: Vec{X: 1}, End: Vec{Y: 1}, Radius: 1}, wantCenter: Vec{}, wantAngle: math.Pi / 2},
+		{arc: Arc{Start: Vec{X: 1}, End: Vec{Y: 1}, Radius: -1}, wantCenter: Vec{X: 1, Y: 1}, wantAngle: -math.Pi / 2},
+		{arc: Arc{Start: Vec{X: 2}, End: Vec{X: -2}, Radius: 2}, wantCenter: Vec{}, wantAngle: math.Pi},
+	} {
+		center, angle, err := test.arc.CenterAngle()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !EqualElem(center, test.wantCenter, tol) || math.Abs(angle-test.wantAngle) > 1e-3 {
+			t.Errorf("%+v: want center,angle=%v,%v, got %v,%v", test.arc, test.wantCenter, test.wantAngle, center, angle)
+		}
+		r := math.Abs(test.arc.Radius)
+		if got := test.arc.Length(); math.Abs(got-math.Abs(test.wantAngle)*r) > 1e-2 {
+			t.Errorf("%+v: want length %v, got %v", test.arc, math.Abs(test.wantAngle)*r, got)
+		}
+		const facets = 8
+		pts, err := test.arc.AppendPolyline(nil, facets)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pts) != facets+1 || pts[0] != test.arc.Start || pts[facets] != test.arc.End {
+			t.Fatalf("%+v: bad polyline endpoints or length: %v", test.arc, pts)
+		}
+		for _, p := range pts {
+			if math.Abs(Norm(Sub(p, center))-r) > 1e-3 {
+				t.Errorf("%+v: point %v not on arc circle", test.arc, p)
+			}
+		}
+	}
+	_, err := Arc{Start: Vec{X: 1}, End: Vec{X: 10}, Radius: 1}.AppendPolyline(nil, 4)
+	if err == nil {
+		t.Error("expected error for arc radius too small")
+	}
+}
+
+func TestPolygon_ChamferSingleFacet(t *testing.T) {
+	// Single facet smoothing emits neither the corner nor any smoothing vertices.
+	for _, setup := range []func(*PolygonControlPoint){
+		func(v *PolygonControlPoint) { v.Chamfer(0.25) },
+		func(v *PolygonControlPoint) { v.Smooth(0.25, 1) },
+	} {
+		var poly PolygonBuilder
+		poly.AddXY(0, 0)
+		setup(poly.AddXY(1, 0))
+		poly.AddXY(1, 1)
+		got, err := poly.AppendVecs(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Vec{{X: 0, Y: 0}, {X: 1, Y: 1}}
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	}
+}
+
+func TestPolygon_FilletLeg(t *testing.T) {
+	const tol = 1e-4
+	const leg = 0.25
+	// Square corner: leg length of smoothing equals its radius.
+	var poly, want PolygonBuilder
+	for _, p := range [...]*PolygonBuilder{&poly, &want} {
+		p.AddXY(0, 0)
+		p.AddXY(1, 0)
+		p.AddXY(1, 1)
+	}
+	poly.verts[1].FilletLeg(leg, 5)
+	want.verts[1].Smooth(leg, 5)
+	got, err := poly.AppendVecs(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantVecs, err := want.AppendVecs(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(wantVecs) {
+		t.Fatalf("want %d vertices, got %d", len(wantVecs), len(got))
+	}
+	for i := range got {
+		if !EqualElem(got[i], wantVecs[i], tol) {
+			t.Errorf("vertex %d: want %v, got %v", i, wantVecs[i], got[i])
+		}
+	}
+
+	// Non square corner: chamfer vertices lie leg away from corner along edges.
+	poly.Reset()
+	poly.AddXY(0, 0)
+	corner := Vec{X: 2, Y: 0}
+	poly.Add(corner).ChamferLeg(leg)
+	poly.AddXY(0, 2)
+	got, err = poly.AppendVecs(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("want 4 chamfered triangle vertices, got %v", got)
+	}
+	for _, v := range got[1:3] {
+		if d := Norm(Sub(v, corner)); math.Abs(d-leg) > tol {
+			t.Errorf("chamfer vertex %v at distance %v from corner, want %v", v, d, leg)
+		}
+	}
+}
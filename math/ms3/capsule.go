@@ -0,0 +1,57 @@
+package ms3
+
+import "github.com/soypat/glgl/math/ms1"
+
+// Capsule is the volume formed by all points within Radius of the Segment.
+type Capsule struct {
+	Segment Line
+	Radius  float32
+}
+
+// Intersects returns true if the capsules overlap or touch.
+func (c Capsule) Intersects(other Capsule) bool {
+	c1, c2, _, _ := ClosestPointsSegments(c.Segment[0], c.Segment[1], other.Segment[0], other.Segment[1])
+	r := c.Radius + other.Radius
+	return DistanceSquared(c1, c2) <= r*r
+}
+
+// ClosestPointsSegments returns the closest points c1 and c2 between the segment
+// from p1 to q1 and the segment from p2 to q2. s and t are the parameters of c1 and c2
+// along their respective segments such that c1 = p1 + s*(q1-p1) and c2 = p2 + t*(q2-p2).
+// Degenerate segments of zero length are treated as points.
+func ClosestPointsSegments(p1, q1, p2, q2 Vec) (c1, c2 Vec, s, t float32) {
+	// See Christer Ericson's "Real-Time Collision Detection", section 5.1.9.
+	d1 := Sub(q1, p1)
+	d2 := Sub(q2, p2)
+	r := Sub(p1, p2)
+	a := Norm2(d1)
+	e := Norm2(d2)
+	f := Dot(d2, r)
+	switch {
+	case a == 0 && e == 0:
+		// Both segments are points.
+	case a == 0:
+		t = ms1.Clamp(f/e, 0, 1)
+	case e == 0:
+		s = ms1.Clamp(-Dot(d1, r)/a, 0, 1)
+	default:
+		c := Dot(d1, r)
+		b := Dot(d1, d2)
+		denom := a*e - b*b
+		if denom != 0 {
+			// Segments not parallel, clamp closest point of the infinite lines.
+			s = ms1.Clamp((b*f-c*e)/denom, 0, 1)
+		}
+		t = (b*s + f) / e
+		if t < 0 {
+			t = 0
+			s = ms1.Clamp(-c/a, 0, 1)
+		} else if t > 1 {
+			t = 1
+			s = ms1.Clamp((b-c)/a, 0, 1)
+		}
+	}
+	c1 = Add(p1, Scale(s, d1))
+	c2 = Add(p2, Scale(t, d2))
+	return c1, c2, s, t
+}
@@ -0,0 +1,72 @@
+package ms3
+
+// Frustum is a convex volume bounded by 6 planes with normals pointing towards
+// the inside of the volume. A view frustum can be obtained from a view-projection
+// matrix with [Mat4.FrustumPlanes].
+type Frustum [6]Plane
+
+// Containment is the result of testing a volume against a [Frustum].
+type Containment uint8
+
+const (
+	// ContainmentOutside indicates the volume is fully outside the frustum.
+	ContainmentOutside Containment = iota
+	// ContainmentIntersecting indicates the volume may be partially within the frustum.
+	ContainmentIntersecting
+	// ContainmentInside indicates the volume is fully within the frustum.
+	ContainmentInside
+)
+
+// ContainsBox returns false if b is guaranteed to be outside the frustum.
+// The test is conservative: boxes near the frustum's corners may be
+// reported as contained though they lie outside the frustum.
+func (f Frustum) ContainsBox(b Box) bool {
+	return f.ClassifyBox(b) != ContainmentOutside
+}
+
+// ContainsSphere returns false if s is guaranteed to be outside the frustum.
+// The test is conservative, see [Frustum.ContainsBox].
+func (f Frustum) ContainsSphere(s Sphere) bool {
+	return f.ClassifySphere(s) != ContainmentOutside
+}
+
+// ClassifyBox returns whether b is inside, outside or intersecting the frustum.
+// Like [Frustum.ContainsBox] boxes reported as intersecting may lie outside the frustum.
+func (f Frustum) ClassifyBox(b Box) Containment {
+	result := ContainmentInside
+	for _, pl := range f {
+		// Vertices furthest along and against the plane normal.
+		positive, negative := b.Min, b.Max
+		if pl.Normal.X >= 0 {
+			positive.X, negative.X = b.Max.X, b.Min.X
+		}
+		if pl.Normal.Y >= 0 {
+			positive.Y, negative.Y = b.Max.Y, b.Min.Y
+		}
+		if pl.Normal.Z >= 0 {
+			positive.Z, negative.Z = b.Max.Z, b.Min.Z
+		}
+		if pl.SignedDistance(positive) < 0 {
+			return ContainmentOutside
+		} else if pl.SignedDistance(negative) < 0 {
+			result = ContainmentIntersecting
+		}
+	}
+	return result
+}
+
+// ClassifySphere returns whether s is inside, outside or intersecting the frustum.
+// The frustum planes must be normalized. Like [Frustum.ContainsSphere]
+// spheres reported as intersecting may lie outside the frustum.
+func (f Frustum) ClassifySphere(s Sphere) Containment {
+	result := ContainmentInside
+	for _, pl := range f {
+		dist := pl.SignedDistance(s.Center)
+		if dist < -s.Radius {
+			return ContainmentOutside
+		} else if dist < s.Radius {
+			result = ContainmentIntersecting
+		}
+	}
+	return result
+}
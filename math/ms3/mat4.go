@@ -2,7 +2,6 @@ package ms3
 
 import (
 	math "github.com/chewxy/math32"
-	"github.com/soypat/glgl/math/ms1"
 )
 
 // Mat4 is a 4x4 matrix.
@@ -24,6 +23,26 @@ func NewMat4(v []float32) (m Mat4) {
 	return m
 }
 
+// Mat4FromCols returns the affine transform with the upper left 3x3 block columns
+// set to c0, c1 and c2 and the translation set to t. The bottom row is (0,0,0,1).
+func Mat4FromCols(c0, c1, c2, t Vec) Mat4 {
+	return Mat4{
+		c0.X, c1.X, c2.X, t.X,
+		c0.Y, c1.Y, c2.Y, t.Y,
+		c0.Z, c1.Z, c2.Z, t.Z,
+		0, 0, 0, 1}
+}
+
+// Mat4FromRows returns the affine transform with the upper left 3x3 block rows
+// set to r0, r1 and r2 and the translation set to t. The bottom row is (0,0,0,1).
+func Mat4FromRows(r0, r1, r2, t Vec) Mat4 {
+	return Mat4{
+		r0.X, r0.Y, r0.Z, t.X,
+		r1.X, r1.Y, r1.Z, t.Y,
+		r2.X, r2.Y, r2.Z, t.Z,
+		0, 0, 0, 1}
+}
+
 // IdentityMat4 returns the identity 4x4 matrix.
 func IdentityMat4() Mat4 {
 	return Mat4{
@@ -73,6 +92,140 @@ func RotatingMat4(angleRadians float32, axis Vec) Mat4 {
 	}
 }
 
+// Orthographic returns an orthographic projection matrix which maps the view
+// volume bounded by left, right, bottom, top and the near and far clipping
+// plane distances to OpenGL clip space. Like glOrtho the camera looks down the
+// negative Z axis so the near and far planes are located at z=-near and z=-far.
+func Orthographic(left, right, bottom, top, near, far float32) Mat4 {
+	rl := 1 / (right - left)
+	tb := 1 / (top - bottom)
+	fn := 1 / (far - near)
+	return Mat4{
+		2 * rl, 0, 0, -(right + left) * rl,
+		0, 2 * tb, 0, -(top + bottom) * tb,
+		0, 0, -2 * fn, -(far + near) * fn,
+		0, 0, 0, 1,
+	}
+}
+
+// OrthographicFromBox returns the orthographic projection matrix whose view volume
+// is exactly b. b is expected in view space where the camera looks down the negative
+// Z axis, so b.Max.Z maps to the near plane and b.Min.Z to the far plane.
+// For shadow mapping b is usually the scene bounds transformed to the light's view space.
+func OrthographicFromBox(b Box) Mat4 {
+	return Orthographic(b.Min.X, b.Max.X, b.Min.Y, b.Max.Y, -b.Max.Z, -b.Min.Z)
+}
+
+// ShadowMatrix returns the matrix which projects geometry onto plane along the rays
+// emanating from the light at homogeneous position (lightPos, lightW). Use lightW=1
+// for point lights and lightW=0 for directional lights, in which case lightPos is the
+// direction towards the light. The result has a non-unit w component for point lights
+// so the perspective divide must be performed, which is done by the GPU when the
+// matrix is part of the vertex transform. Geometry must be between the light and
+// the plane to cast a shadow on it.
+func ShadowMatrix(plane Plane, lightPos Vec, lightW float32) Mat4 {
+	a, b, c, d := plane.Normal.X, plane.Normal.Y, plane.Normal.Z, plane.D
+	lx, ly, lz, lw := lightPos.X, lightPos.Y, lightPos.Z, lightW
+	dot := a*lx + b*ly + c*lz + d*lw
+	return Mat4{
+		dot - lx*a, -lx * b, -lx * c, -lx * d,
+		-ly * a, dot - ly*b, -ly * c, -ly * d,
+		-lz * a, -lz * b, dot - lz*c, -lz * d,
+		-lw * a, -lw * b, -lw * c, dot - lw*d}
+}
+
+// ComposeTRS returns the 4x4 matrix which scales by scale, then rotates by
+// rotation and finally translates by translation, i.e: T*R*S.
+// rotation is normalized before use.
+func ComposeTRS(translation Vec, rotation Quat, scale Vec) Mat4 {
+	r := RotatingMat3(rotation.Unit())
+	return Mat4{
+		r.x00 * scale.X, r.x01 * scale.Y, r.x02 * scale.Z, translation.X,
+		r.x10 * scale.X, r.x11 * scale.Y, r.x12 * scale.Z, translation.Y,
+		r.x20 * scale.X, r.x21 * scale.Y, r.x22 * scale.Z, translation.Z,
+		0, 0, 0, 1,
+	}
+}
+
+// ComposeTRSShear returns the 4x4 matrix which scales by scale, shears by shear,
+// rotates by rotation and finally translates by translation, i.e: T*R*H*S where
+// H is the upper triangular shear matrix
+//
+//	| 1  shear.X  shear.Y |
+//	| 0  1        shear.Z |
+//	| 0  0        1       |
+//
+// so shear.X, shear.Y and shear.Z are the XY, XZ and YZ shear factors, respectively.
+// It is the inverse of [Mat4.DecomposeFull]. rotation is normalized before use.
+func ComposeTRSShear(translation Vec, rotation Quat, scale, shear Vec) Mat4 {
+	hs := mat3(
+		scale.X, shear.X*scale.Y, shear.Y*scale.Z,
+		0, scale.Y, shear.Z*scale.Z,
+		0, 0, scale.Z,
+	)
+	r := MulMat3(RotatingMat3(rotation.Unit()), hs)
+	return Mat4{
+		r.x00, r.x01, r.x02, translation.X,
+		r.x10, r.x11, r.x12, translation.Y,
+		r.x20, r.x21, r.x22, translation.Z,
+		0, 0, 0, 1,
+	}
+}
+
+// Decompose decomposes the affine transform m into a translation, a rotation
+// and a scale such that ComposeTRS(Decompose()) returns m. If m contains shear
+// the decomposition is not exact, use [Mat4.DecomposeFull] in that case.
+// A negative determinant is represented by a negative X scale.
+func (m Mat4) Decompose() (translation Vec, rotation Quat, scale Vec) {
+	translation, rotation, scale, _ = m.DecomposeFull()
+	return translation, rotation, scale
+}
+
+// DecomposeFull decomposes the affine transform m into a translation, a rotation,
+// a scale and a shear such that ComposeTRSShear(DecomposeFull()) returns m.
+// A non-zero shear indicates m can not be represented by [ComposeTRS].
+// A negative determinant is represented by a negative X scale.
+// The upper 3x3 of m must be invertible.
+func (m Mat4) DecomposeFull() (translation Vec, rotation Quat, scale, shear Vec) {
+	// Gram-Schmidt orthogonalization of the columns yields R and the upper
+	// triangular matrix H*S. See Graphics Gems II "Decomposing a matrix into simple transformations".
+	translation = Vec{X: m.x03, Y: m.x13, Z: m.x23}
+	c0 := Vec{X: m.x00, Y: m.x10, Z: m.x20}
+	c1 := Vec{X: m.x01, Y: m.x11, Z: m.x21}
+	c2 := Vec{X: m.x02, Y: m.x12, Z: m.x22}
+	flip := Dot(c0, Cross(c1, c2)) < 0
+	if flip {
+		c0 = Scale(-1, c0)
+	}
+	scale.X = Norm(c0)
+	c0 = Scale(1/scale.X, c0)
+
+	shear.X = Dot(c0, c1)
+	c1 = Sub(c1, Scale(shear.X, c0))
+	scale.Y = Norm(c1)
+	c1 = Scale(1/scale.Y, c1)
+
+	shear.Y = Dot(c0, c2)
+	shear.Z = Dot(c1, c2)
+	c2 = Sub(c2, Add(Scale(shear.Y, c0), Scale(shear.Z, c1)))
+	scale.Z = Norm(c2)
+	c2 = Scale(1/scale.Z, c2)
+
+	// Factor scale out of the upper triangular matrix's columns.
+	shear.X /= scale.Y
+	shear.Y /= scale.Z
+	shear.Z /= scale.Z
+	if flip {
+		scale.X = -scale.X
+	}
+	rotation = rotationMat3ToQuat(mat3(
+		c0.X, c1.X, c2.X,
+		c0.Y, c1.Y, c2.Y,
+		c0.Z, c1.Z, c2.Z,
+	))
+	return translation, rotation, scale, shear
+}
+
 // MulMat4 multiplies two 4x4 matrices and returns the result.
 func MulMat4(a, b Mat4) Mat4 {
 	m := Mat4{}
@@ -103,6 +256,39 @@ func (a Mat4) MulPosition(b Vec) Vec {
 		Z: a.x20*b.X + a.x21*b.Y + a.x22*b.Z + a.x23}
 }
 
+// MulPositions stores in dst the positions of src multiplied by a, same as calling
+// [Mat4.MulPosition] on each element. dst and src may be the same slice for in-place
+// transformation. MulPositions panics if dst is shorter than src.
+func (a Mat4) MulPositions(dst, src []Vec) {
+	dst = dst[:len(src)] // Panic early and eliminate bounds checks in loop.
+	x00, x01, x02, x03 := a.x00, a.x01, a.x02, a.x03
+	x10, x11, x12, x13 := a.x10, a.x11, a.x12, a.x13
+	x20, x21, x22, x23 := a.x20, a.x21, a.x22, a.x23
+	for i, v := range src {
+		dst[i] = Vec{
+			X: x00*v.X + x01*v.Y + x02*v.Z + x03,
+			Y: x10*v.X + x11*v.Y + x12*v.Z + x13,
+			Z: x20*v.X + x21*v.Y + x22*v.Z + x23,
+		}
+	}
+}
+
+// MulDirections is like [Mat4.MulPositions] but ignores the translation of a,
+// which is to say the vectors of src are transformed with homogeneous coordinate w=0.
+func (a Mat4) MulDirections(dst, src []Vec) {
+	dst = dst[:len(src)]
+	x00, x01, x02 := a.x00, a.x01, a.x02
+	x10, x11, x12 := a.x10, a.x11, a.x12
+	x20, x21, x22 := a.x20, a.x21, a.x22
+	for i, v := range src {
+		dst[i] = Vec{
+			X: x00*v.X + x01*v.Y + x02*v.Z,
+			Y: x10*v.X + x11*v.Y + x12*v.Z,
+			Z: x20*v.X + x21*v.Y + x22*v.Z,
+		}
+	}
+}
+
 // MulBox rotates/translates a 3d bounding box and resizes for axis-alignment.
 func (a Mat4) MulBox(box Box) Box {
 	// Below is equivalent code:
@@ -218,6 +404,81 @@ func (m Mat4) Array() (rowmajor [16]float32) {
 	return rowmajor
 }
 
+// FrustumPlanes extracts the 6 normalized clipping planes of a view-projection
+// matrix using the Gribb-Hartmann method. The planes are returned in the order
+// left, right, bottom, top, near, far. Plane normals point towards the inside of
+// the frustum so points within the frustum have positive signed distance to all planes.
+// The matrix is expected to map to OpenGL clip space, i.e: -w <= x,y,z <= w.
+func (m Mat4) FrustumPlanes() [6]Plane {
+	return [6]Plane{
+		0: planeFromCoefs(m.x30+m.x00, m.x31+m.x01, m.x32+m.x02, m.x33+m.x03), // Left.
+		1: planeFromCoefs(m.x30-m.x00, m.x31-m.x01, m.x32-m.x02, m.x33-m.x03), // Right.
+		2: planeFromCoefs(m.x30+m.x10, m.x31+m.x11, m.x32+m.x12, m.x33+m.x13), // Bottom.
+		3: planeFromCoefs(m.x30-m.x10, m.x31-m.x11, m.x32-m.x12, m.x33-m.x13), // Top.
+		4: planeFromCoefs(m.x30+m.x20, m.x31+m.x21, m.x32+m.x22, m.x33+m.x23), // Near.
+		5: planeFromCoefs(m.x30-m.x20, m.x31-m.x21, m.x32-m.x22, m.x33-m.x23), // Far.
+	}
+}
+
+// VecRow returns the first 3 elements of the ith row as a Vec and the
+// 4th element as w. VecRow panics if i is not in the range 0..3.
+func (m Mat4) VecRow(i int) (v Vec, w float32) {
+	switch i {
+	case 0:
+		return Vec{X: m.x00, Y: m.x01, Z: m.x02}, m.x03
+	case 1:
+		return Vec{X: m.x10, Y: m.x11, Z: m.x12}, m.x13
+	case 2:
+		return Vec{X: m.x20, Y: m.x21, Z: m.x22}, m.x23
+	case 3:
+		return Vec{X: m.x30, Y: m.x31, Z: m.x32}, m.x33
+	}
+	panic("out of bounds")
+}
+
+// VecCol returns the first 3 elements of the jth column as a Vec and the
+// 4th element as w. VecCol panics if j is not in the range 0..3.
+func (m Mat4) VecCol(j int) (v Vec, w float32) {
+	switch j {
+	case 0:
+		return Vec{X: m.x00, Y: m.x10, Z: m.x20}, m.x30
+	case 1:
+		return Vec{X: m.x01, Y: m.x11, Z: m.x21}, m.x31
+	case 2:
+		return Vec{X: m.x02, Y: m.x12, Z: m.x22}, m.x32
+	case 3:
+		return Vec{X: m.x03, Y: m.x13, Z: m.x23}, m.x33
+	}
+	panic("out of bounds")
+}
+
+// Translation returns the translation component of an affine transformation matrix.
+func (m Mat4) Translation() Vec {
+	return Vec{X: m.x03, Y: m.x13, Z: m.x23}
+}
+
+// Right returns the local X axis of an affine transformation matrix (first column).
+func (m Mat4) Right() Vec {
+	return Vec{X: m.x00, Y: m.x10, Z: m.x20}
+}
+
+// Up returns the local Y axis of an affine transformation matrix (second column).
+func (m Mat4) Up() Vec {
+	return Vec{X: m.x01, Y: m.x11, Z: m.x21}
+}
+
+// Forward returns the negated local Z axis of an affine transformation matrix
+// (third column) following the OpenGL convention of looking down the negative Z axis.
+func (m Mat4) Forward() Vec {
+	return Vec{X: -m.x02, Y: -m.x12, Z: -m.x22}
+}
+
+// String returns the matrix formatted as aligned rows. Values near zero are printed as 0.
+func (m Mat4) String() string {
+	a := m.Array()
+	return formatMat(a[:], 4)
+}
+
 // RotatingBetweenVecsMat4 returns the rotation matrix that transforms "start" onto the same direction as "dest".
 func RotatingBetweenVecsMat4(start, dest Vec) Mat4 {
 	// is either vector == 0?
@@ -258,22 +519,18 @@ func RotatingBetweenVecsMat4(start, dest Vec) Mat4 {
 	return vx.AsMat4()
 }
 
-// EqualMat4 tests the equality of 4x4 matrices.
+// EqualMat4 tests the equality of 4x4 matrices to within an absolute tolerance
+// for each element. EqualMat4 returns false if any element of a or b is NaN,
+// so a matrix returned by a singular [Mat4.Inverse] never compares equal.
 func EqualMat4(a, b Mat4, tolerance float32) bool {
-	return ms1.EqualWithinAbs(a.x00, b.x00, tolerance) &&
-		ms1.EqualWithinAbs(a.x01, b.x01, tolerance) &&
-		ms1.EqualWithinAbs(a.x02, b.x02, tolerance) &&
-		ms1.EqualWithinAbs(a.x03, b.x03, tolerance) &&
-		ms1.EqualWithinAbs(a.x10, b.x10, tolerance) &&
-		ms1.EqualWithinAbs(a.x11, b.x11, tolerance) &&
-		ms1.EqualWithinAbs(a.x12, b.x12, tolerance) &&
-		ms1.EqualWithinAbs(a.x13, b.x13, tolerance) &&
-		ms1.EqualWithinAbs(a.x20, b.x20, tolerance) &&
-		ms1.EqualWithinAbs(a.x21, b.x21, tolerance) &&
-		ms1.EqualWithinAbs(a.x22, b.x22, tolerance) &&
-		ms1.EqualWithinAbs(a.x23, b.x23, tolerance) &&
-		ms1.EqualWithinAbs(a.x30, b.x30, tolerance) &&
-		ms1.EqualWithinAbs(a.x31, b.x31, tolerance) &&
-		ms1.EqualWithinAbs(a.x32, b.x32, tolerance) &&
-		ms1.EqualWithinAbs(a.x33, b.x33, tolerance)
+	aa, ba := a.Array(), b.Array()
+	return equalElems(aa[:], ba[:], tolerance, 0)
+}
+
+// EqualMat4Rel tests the equality of 4x4 matrices to within a tolerance relative to the
+// largest magnitude of each pair of elements: |a-b| <= relTol*max(|a|,|b|). Elements which
+// are exactly equal always compare equal. The NaN policy is the same as [EqualMat4].
+func EqualMat4Rel(a, b Mat4, relTol float32) bool {
+	aa, ba := a.Array(), b.Array()
+	return equalElems(aa[:], ba[:], 0, relTol)
 }
@@ -3,7 +3,10 @@ package ms3
 import (
 	"fmt"
 	"math"
+	"math/rand"
+	"slices"
 	"testing"
+	"unsafe"
 )
 
 func TestRotation(t *testing.T) {
@@ -55,3 +58,928 @@ func printMat(a Mat3) {
 	fmt.Printf("%f %f %f \n", a.x10, a.x11, a.x12)
 	fmt.Printf("%f %f %f \n", a.x20, a.x21, a.x22)
 }
+
+func TestFlattenVecs(t *testing.T) {
+	vs := []Vec{{X: 1, Y: 2, Z: 3}, {X: -4, Y: 5, Z: -6}}
+	flat := FlattenVecs(nil, vs)
+	want := []float32{1, 2, 3, -4, 5, -6}
+	if len(flat) != len(want) {
+		t.Fatalf("want %d floats, got %d", len(want), len(flat))
+	}
+	for i := range want {
+		if flat[i] != want[i] {
+			t.Errorf("want %v, got %v", want, flat)
+			break
+		}
+	}
+	got := AppendVecsFromFloats(nil, flat)
+	if len(got) != len(vs) || got[0] != vs[0] || got[1] != vs[1] {
+		t.Errorf("round trip want %v, got %v", vs, got)
+	}
+}
+
+func TestBoxOctants(t *testing.T) {
+	const tol = 1e-6
+	box := NewBox(-1, -2, -3, 3, 2, 1)
+	octants := box.Octants()
+	verts := box.Vertices()
+	var volume float32
+	for i, oct := range octants {
+		volume += oct.Volume()
+		if !EqualElem(oct.Size(), Scale(0.5, box.Size()), tol) {
+			t.Errorf("octant %d has wrong size %v", i, oct.Size())
+		}
+		if !oct.Contains(verts[i]) || !oct.Contains(box.Center()) {
+			t.Errorf("octant %d does not contain vertex %d or box center", i, i)
+		}
+		if !box.ContainsBox(oct) {
+			t.Errorf("octant %d not contained in box", i)
+		}
+	}
+	if math.Abs(float64(volume-box.Volume())) > tol {
+		t.Errorf("octant volume sum %v does not match box volume %v", volume, box.Volume())
+	}
+}
+
+func TestBoxSubdivide(t *testing.T) {
+	const tol = 1e-5
+	const nx, ny, nz = 2, 3, 4
+	box := NewBox(-1, 2, -3, 5, 10, 1)
+	cells := box.Subdivide(nx, ny, nz)
+	if len(cells) != nx*ny*nz {
+		t.Fatalf("want %d cells, got %d", nx*ny*nz, len(cells))
+	}
+	cellSize := DivElem(box.Size(), Vec{X: nx, Y: ny, Z: nz})
+	var union Box
+	var volume float32
+	for i, cell := range cells {
+		if !EqualElem(cell.Size(), cellSize, tol) || !box.ContainsBox(cell) {
+			t.Errorf("cell %d %v has wrong size or is not contained in box", i, cell)
+		}
+		union = union.Union(cell)
+		volume += cell.Volume()
+	}
+	// Cells are indexed x-major.
+	if last := cells[len(cells)-1]; !EqualElem(cells[0].Min, box.Min, tol) || !EqualElem(last.Max, box.Max, tol) ||
+		!EqualElem(cells[1].Min, Vec{X: box.Min.X + cellSize.X, Y: box.Min.Y, Z: box.Min.Z}, tol) {
+		t.Errorf("cells not indexed x-major: first %v, second %v, last %v", cells[0], cells[1], last)
+	}
+	if !union.Equal(box, tol) {
+		t.Errorf("cell union %v does not match box %v", union, box)
+	}
+	if math.Abs(float64(volume-box.Volume())) > tol*float64(box.Volume()) {
+		t.Errorf("cell volume sum %v does not match box volume %v", volume, box.Volume())
+	}
+}
+
+func TestMatString(t *testing.T) {
+	m3 := mat3(-1e-8, -1, 0, 1, 0, 0, 0, 0, 1)
+	const want3 = "[ 0 -1  0]\n[ 1  0  0]\n[ 0  0  1]"
+	if got := m3.String(); got != want3 {
+		t.Errorf("want Mat3 string\n%s\ngot\n%s", want3, got)
+	}
+	m4 := IdentityMat4()
+	m4.x03 = 2.5
+	const want4 = "[  1   0   0 2.5]\n[  0   1   0   0]\n[  0   0   1   0]\n[  0   0   0   1]"
+	if got := m4.String(); got != want4 {
+		t.Errorf("want Mat4 string\n%s\ngot\n%s", want4, got)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	p, q := Vec{X: 1, Y: 2, Z: 3}, Vec{X: 3, Y: 5, Z: 9}
+	if got := Distance(p, q); math.Abs(float64(got-7)) > 1e-6 {
+		t.Errorf("want distance 7, got %v", got)
+	}
+	if got := DistanceSquared(p, q); math.Abs(float64(got-49)) > 1e-6 {
+		t.Errorf("want squared distance 49, got %v", got)
+	}
+}
+
+func TestBoxRandomPoint(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	box := NewBox(-1, -2, -3, 3, 2, 1)
+	var faceCount [6]int
+	const n = 6000
+	for i := 0; i < n; i++ {
+		p := box.RandomPoint(rng)
+		if !box.Contains(p) {
+			t.Fatalf("point %v not in box", p)
+		}
+		p = box.RandomSurfacePoint(rng)
+		if !box.Contains(p) {
+			t.Fatalf("surface point %v not in box", p)
+		}
+		onFace := 0
+		for j, onSide := range [6]bool{
+			p.X == box.Min.X, p.X == box.Max.X,
+			p.Y == box.Min.Y, p.Y == box.Max.Y,
+			p.Z == box.Min.Z, p.Z == box.Max.Z,
+		} {
+			if onSide {
+				onFace++
+				faceCount[j]++
+			}
+		}
+		if onFace == 0 {
+			t.Fatalf("surface point %v not on box surface", p)
+		}
+	}
+	// All faces have area 16, expect even distribution.
+	for j, count := range faceCount {
+		if count < n/6*8/10 || count > n/6*12/10 {
+			t.Errorf("face %d sampled %d times, expected around %d", j, count, n/6)
+		}
+	}
+}
+
+func TestSampleMeshSurface(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tris := []Triangle{
+		{{X: 0}, {X: 1}, {Y: 2}},          // Area 1.
+		{{X: 10}, {X: 13}, {X: 10, Y: 2}}, // Area 3.
+		{{X: 20}, {X: 21}, {X: 22}},       // Degenerate, area 0.
+	}
+	const n = 4000
+	points := SampleMeshSurface(tris, n, rng)
+	if len(points) != n {
+		t.Fatalf("want %d points, got %d", n, len(points))
+	}
+	inFirst := 0
+	for _, p := range points {
+		var u float32
+		switch {
+		case p.X < 5:
+			inFirst++
+			u = p.X + p.Y/2
+		case p.X < 15:
+			u = (p.X-10)/3 + p.Y/2
+		default:
+			t.Fatalf("point %v sampled from degenerate triangle", p)
+		}
+		if p.Z != 0 || p.X < 0 || p.Y < 0 || u > 1+1e-6 {
+			t.Fatalf("point %v outside of triangles", p)
+		}
+	}
+	if inFirst < n/4*8/10 || inFirst > n/4*12/10 {
+		t.Errorf("first triangle sampled %d times, expected around %d", inFirst, n/4)
+	}
+	if SampleMeshSurface(tris[2:], n, rng) != nil {
+		t.Error("expected nil points for zero area mesh")
+	}
+	if SampleMeshSurface(tris, -1, rng) != nil {
+		t.Error("expected nil points for negative n")
+	}
+}
+
+func TestQuatRotateTowards(t *testing.T) {
+	const tol = 1e-5
+	start := QuatIdent()
+	target := RotationQuat(math.Pi/2, Vec{Z: 1})
+	for _, test := range []struct {
+		target     Quat
+		maxRadians float32
+		want       Quat
+	}{
+		{target: target, maxRadians: math.Pi / 4, want: RotationQuat(math.Pi/4, Vec{Z: 1})},
+		{target: target.Scale(-1), maxRadians: math.Pi / 4, want: RotationQuat(math.Pi/4, Vec{Z: 1})},
+		{target: target, maxRadians: math.Pi, want: target},
+		{target: target, maxRadians: math.Pi / 2, want: target},
+	} {
+		got := start.RotateTowards(test.target, test.maxRadians)
+		if math.Abs(float64(got.Dot(test.want))) < 1-tol {
+			t.Errorf("RotateTowards(%v, %v) want %v, got %v", test.target, test.maxRadians, test.want, got)
+		}
+	}
+}
+
+func TestTriangleArea(t *testing.T) {
+	const tol = 1e-6
+	tri := Triangle{{X: 10}, {X: 13}, {X: 10, Y: 2}}
+	if got := tri.Area(); math.Abs(float64(got-3)) > tol {
+		t.Errorf("want area 3, got %v", got)
+	}
+	l1, l2, l3 := Sort(3, 1, 2)
+	if l1 != 1 || l2 != 2 || l3 != 3 {
+		t.Errorf("want sorted 1 2 3, got %v %v %v", l1, l2, l3)
+	}
+}
+
+func TestFrustumPlanes(t *testing.T) {
+	const tol = 1e-6
+	// Maps box centered at (1,0,0) of half size 2 to clip space.
+	m := MulMat4(ScalingMat4(Vec{X: 0.5, Y: 0.5, Z: 0.5}), TranslatingMat4(Vec{X: -1}))
+	planes := m.FrustumPlanes()
+	wantPlanes := [6]Plane{
+		{Normal: Vec{X: 1}, D: 1},
+		{Normal: Vec{X: -1}, D: 3},
+		{Normal: Vec{Y: 1}, D: 2},
+		{Normal: Vec{Y: -1}, D: 2},
+		{Normal: Vec{Z: 1}, D: 2},
+		{Normal: Vec{Z: -1}, D: 2},
+	}
+	for i, got := range planes {
+		want := wantPlanes[i]
+		if !EqualElem(got.Normal, want.Normal, tol) || math.Abs(float64(got.D-want.D)) > tol {
+			t.Errorf("plane %d want %v, got %v", i, want, got)
+		}
+		if got.SignedDistance(Vec{X: 1}) <= 0 {
+			t.Errorf("plane %d: frustum center not inside", i)
+		}
+	}
+	if planes[1].SignedDistance(Vec{X: 4}) >= 0 {
+		t.Error("point right of frustum not outside of right plane")
+	}
+}
+
+func TestFrustumClassify(t *testing.T) {
+	// Frustum is the box (-1,-1,-1) to (1,1,1).
+	f := Frustum(IdentityMat4().FrustumPlanes())
+	for _, test := range []struct {
+		box    Box
+		sphere Sphere
+		want   Containment
+	}{
+		{box: NewCenteredBox(Vec{}, Vec{X: 1, Y: 1, Z: 1}), sphere: Sphere{Radius: 0.5}, want: ContainmentInside},
+		{box: NewCenteredBox(Vec{X: 1}, Vec{X: 1, Y: 1, Z: 1}), sphere: Sphere{Center: Vec{X: 1}, Radius: 0.5}, want: ContainmentIntersecting},
+		{box: NewCenteredBox(Vec{Z: -3}, Vec{X: 1, Y: 1, Z: 1}), sphere: Sphere{Center: Vec{Z: -3}, Radius: 0.5}, want: ContainmentOutside},
+		{box: NewCenteredBox(Vec{}, Vec{X: 4, Y: 4, Z: 4}), sphere: Sphere{Radius: 2}, want: ContainmentIntersecting},
+	} {
+		if got := f.ClassifyBox(test.box); got != test.want {
+			t.Errorf("ClassifyBox(%v) want %v, got %v", test.box, test.want, got)
+		}
+		if got := f.ClassifySphere(test.sphere); got != test.want {
+			t.Errorf("ClassifySphere(%v) want %v, got %v", test.sphere, test.want, got)
+		}
+		wantContains := test.want != ContainmentOutside
+		if f.ContainsBox(test.box) != wantContains || f.ContainsSphere(test.sphere) != wantContains {
+			t.Errorf("Contains mismatch for box %v and sphere %v", test.box, test.sphere)
+		}
+	}
+}
+
+func TestTransform(t *testing.T) {
+	const tol = 1e-5
+	parent := Transform{
+		Position: Vec{X: 1, Y: 2, Z: 3},
+		Rotation: RotationQuat(math.Pi/3, Vec{X: 1, Y: 1}),
+		Scale:    Vec{X: 2, Y: 2, Z: 2},
+	}
+	child := Transform{
+		Position: Vec{X: -1, Z: 0.5},
+		Rotation: RotationQuat(0.3, Vec{Z: 1}),
+		Scale:    Vec{X: 1, Y: 3, Z: 0.5},
+	}
+	p := Vec{X: 0.5, Y: -2, Z: 1}
+	got := parent.TransformPoint(p)
+	want := parent.Matrix().MulPosition(p)
+	if !EqualElem(got, want, tol) {
+		t.Errorf("TransformPoint want %v, got %v", want, got)
+	}
+	back := parent.Inverse().TransformPoint(got)
+	if !EqualElem(back, p, tol) {
+		t.Errorf("Inverse want %v, got %v", p, back)
+	}
+	got = parent.Mul(child).TransformPoint(p)
+	want = parent.TransformPoint(child.TransformPoint(p))
+	if !EqualElem(got, want, tol) {
+		t.Errorf("Mul want %v, got %v", want, got)
+	}
+	if !EqualMat4(parent.Mul(child).Matrix(), MulMat4(parent.Matrix(), child.Matrix()), tol) {
+		t.Error("Mul matrix mismatch")
+	}
+	if got := IdentityTransform().TransformPoint(p); got != p {
+		t.Errorf("identity transform changed point %v to %v", p, got)
+	}
+	d := parent.TransformDirection(Vec{X: 1})
+	if math.Abs(float64(Norm(d)-1)) > tol {
+		t.Errorf("TransformDirection changed length: %v", d)
+	}
+}
+
+func TestRayIntersectBoxNormal(t *testing.T) {
+	const tol = 1e-6
+	box := NewBox(-1, -1, -1, 1, 1, 1)
+	for _, test := range []struct {
+		ray    Ray
+		wantOk bool
+		wantT  float32
+		wantN  Vec
+	}{
+		{ray: Ray{Origin: Vec{X: -3}, Dir: Vec{X: 1}}, wantOk: true, wantT: 2, wantN: Vec{X: -1}},
+		{ray: Ray{Origin: Vec{Y: 5, Z: 0.5}, Dir: Vec{Y: -2}}, wantOk: true, wantT: 2, wantN: Vec{Y: 1}},
+		{ray: Ray{Origin: Vec{}, Dir: Vec{Z: 1}}, wantOk: true, wantT: 1, wantN: Vec{Z: -1}}, // Inside.
+		{ray: Ray{Origin: Vec{X: -3}, Dir: Vec{X: -1}}, wantOk: false},                       // Box behind.
+		{ray: Ray{Origin: Vec{X: -3, Y: 2}, Dir: Vec{X: 1}}, wantOk: false},                  // Parallel miss.
+		{ray: Ray{Origin: Vec{X: -3}, Dir: Vec{}}, wantOk: false},
+	} {
+		gotT, gotN, ok := test.ray.IntersectBoxNormal(box)
+		if ok != test.wantOk {
+			t.Errorf("%+v: want ok=%v", test.ray, test.wantOk)
+			continue
+		} else if !ok {
+			continue
+		}
+		if math.Abs(float64(gotT-test.wantT)) > tol || gotN != test.wantN {
+			t.Errorf("%+v: want t=%v n=%v, got t=%v n=%v", test.ray, test.wantT, test.wantN, gotT, gotN)
+		}
+		if !box.Contains(test.ray.At(gotT)) {
+			t.Errorf("%+v: hit point %v not on box", test.ray, test.ray.At(gotT))
+		}
+	}
+}
+
+func TestClosestPointsSegments(t *testing.T) {
+	const tol = 1e-6
+	for _, test := range []struct {
+		p1, q1, p2, q2 Vec
+		wantC1, wantC2 Vec
+	}{
+		{ // Crossing segments offset in Z.
+			p1: Vec{X: -1}, q1: Vec{X: 1}, p2: Vec{Y: -1, Z: 1}, q2: Vec{Y: 1, Z: 1},
+			wantC1: Vec{}, wantC2: Vec{Z: 1},
+		},
+		{ // Parallel overlapping segments.
+			p1: Vec{}, q1: Vec{X: 2}, p2: Vec{X: 3, Y: 1}, q2: Vec{X: 5, Y: 1},
+			wantC1: Vec{X: 2}, wantC2: Vec{X: 3, Y: 1},
+		},
+		{ // Endpoint to segment interior.
+			p1: Vec{X: -1, Y: 2}, q1: Vec{X: 1, Y: 2}, p2: Vec{X: 3, Y: -1}, q2: Vec{X: 3, Y: 4},
+			wantC1: Vec{X: 1, Y: 2}, wantC2: Vec{X: 3, Y: 2},
+		},
+		{ // Point and segment.
+			p1: Vec{X: 1, Y: 1}, q1: Vec{X: 1, Y: 1}, p2: Vec{}, q2: Vec{X: 4},
+			wantC1: Vec{X: 1, Y: 1}, wantC2: Vec{X: 1},
+		},
+	} {
+		c1, c2, s, u := ClosestPointsSegments(test.p1, test.q1, test.p2, test.q2)
+		if !EqualElem(c1, test.wantC1, tol) || !EqualElem(c2, test.wantC2, tol) {
+			t.Errorf("want closest points %v %v, got %v %v", test.wantC1, test.wantC2, c1, c2)
+		}
+		if !EqualElem(c1, Line{test.p1, test.q1}.Interpolate(s), tol) || !EqualElem(c2, Line{test.p2, test.q2}.Interpolate(u), tol) {
+			t.Errorf("segment parameters %v %v do not match closest points", s, u)
+		}
+	}
+	a := Capsule{Segment: Line{{X: -1}, {X: 1}}, Radius: 0.5}
+	b := Capsule{Segment: Line{{Y: -1, Z: 1}, {Y: 1, Z: 1}}, Radius: 0.6}
+	if !a.Intersects(b) {
+		t.Error("expected capsules to intersect")
+	}
+	b.Radius = 0.4
+	if a.Intersects(b) {
+		t.Error("expected capsules to not intersect")
+	}
+}
+
+func TestOrientTriangles(t *testing.T) {
+	// Tetrahedron with outward facing normals.
+	v := [4]Vec{{X: 0}, {X: 1}, {Y: 1}, {Z: 1}}
+	want := []Triangle{
+		{v[0], v[2], v[1]},
+		{v[0], v[1], v[3]},
+		{v[1], v[2], v[3]},
+		{v[0], v[3], v[2]},
+	}
+	center := Scale(0.25, Add(Add(v[0], v[1]), Add(v[2], v[3])))
+	for i, tri := range want {
+		if Dot(tri.Normal(), Sub(tri.Centroid(), center)) <= 0 {
+			t.Fatalf("bad test triangle %d", i)
+		}
+		if tri.FaceToward(center) != tri.FlipWinding() {
+			t.Errorf("triangle %d not flipped toward center", i)
+		}
+	}
+	for _, flips := range [][]bool{
+		{true, false, false, false},
+		{false, true, false, true},
+		{true, true, true, true},
+	} {
+		got := slices.Clone(want)
+		for i, flip := range flips {
+			if flip {
+				got[i] = got[i].FlipWinding()
+			}
+		}
+		OrientTriangles(got, center)
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("flips %v: triangle %d not oriented outward", flips, i)
+			}
+		}
+	}
+}
+
+func TestBatchSVD(t *testing.T) {
+	const tol = 1e-4
+	rng := rand.New(rand.NewSource(1))
+	mats := make([]Mat3, 64)
+	rots := make([]Mat3, len(mats))
+	for i := range mats {
+		axis := Vec{X: float32(rng.Float64()), Y: float32(rng.Float64()), Z: float32(rng.Float64()) + 0.1}
+		rots[i] = RotatingMat3(RotationQuat(float32(rng.Float64()*3), Unit(axis)))
+		// Rotation times a symmetric positive definite stretch.
+		stretch := mat3(
+			2, 0.1, 0,
+			0.1, 1.5, 0.2,
+			0, 0.2, 1+float32(rng.Float64()))
+		mats[i] = MulMat3(rots[i], stretch)
+	}
+	u := make([]Mat3, len(mats))
+	v := make([]Mat3, len(mats))
+	BatchSVD(mats, u, nil, v)
+	polar := make([]Mat3, len(mats))
+	BatchPolarRotation(polar, mats)
+	vOnly := make([]Mat3, len(mats))
+	BatchSVD(mats, nil, nil, vOnly)
+	for i, a := range mats {
+		U, _, V := a.SVD()
+		if u[i] != U || v[i] != V || vOnly[i] != V {
+			t.Errorf("%d: batch SVD mismatch", i)
+		}
+		if !EqualMat3(polar[i], rots[i], tol) {
+			t.Errorf("%d: polar rotation want\n%v\ngot\n%v", i, rots[i], polar[i])
+		}
+		if want := MulMat3(U, V.Transpose()); !EqualMat3(polar[i], want, 1e-6) {
+			t.Errorf("%d: polar rotation does not match U*Vᵀ of SVD", i)
+		}
+		b, _ := a.svdV()
+		if q, _ := b.QRDecomposition(); q != b.qrRotation() {
+			t.Errorf("%d: qrRotation does not match QR decomposition", i)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	const want = 3847790828 // hash(1 + hash(2 + hash(3)))
+	if got := Hash(1, 2, 3); got != want {
+		t.Errorf("want hash %d, got %d", uint32(want), got)
+	}
+	if f := HashToFloat(1, 2, 3); f < 0 || f >= 1 {
+		t.Errorf("HashToFloat out of [0,1): %v", f)
+	}
+}
+
+func TestOrthographicFromBox(t *testing.T) {
+	const tol = 1e-6
+	box := NewBox(-1, 2, -10, 3, 4, -2)
+	m := OrthographicFromBox(box)
+	if got := m.MulPosition(box.Min); !EqualElem(got, Vec{X: -1, Y: -1, Z: 1}, tol) {
+		t.Errorf("box minimum mapped to %v", got)
+	}
+	if got := m.MulPosition(box.Max); !EqualElem(got, Vec{X: 1, Y: 1, Z: -1}, tol) {
+		t.Errorf("box maximum mapped to %v", got)
+	}
+	if got := Frustum(m.FrustumPlanes()).ClassifyBox(box.ScaleCentered(Vec{X: 0.9, Y: 0.9, Z: 0.9})); got != ContainmentInside {
+		t.Errorf("shrunk box not inside frustum: %v", got)
+	}
+}
+
+func TestMat4Basis(t *testing.T) {
+	const tol = 1e-6
+	translation := Vec{X: 1, Y: 2, Z: 3}
+	m := ComposeTRS(translation, RotationQuat(math.Pi/2, Vec{Z: 1}), Vec{X: 1, Y: 1, Z: 1})
+	if got := m.Translation(); got != translation {
+		t.Errorf("want translation %v, got %v", translation, got)
+	}
+	if got := m.Right(); !EqualElem(got, Vec{Y: 1}, tol) {
+		t.Errorf("want right %v, got %v", Vec{Y: 1}, got)
+	}
+	if got := m.Up(); !EqualElem(got, Vec{X: -1}, tol) {
+		t.Errorf("want up %v, got %v", Vec{X: -1}, got)
+	}
+	if got := m.Forward(); !EqualElem(got, Vec{Z: -1}, tol) {
+		t.Errorf("want forward %v, got %v", Vec{Z: -1}, got)
+	}
+	col, w := m.VecCol(3)
+	if col != translation || w != 1 {
+		t.Errorf("want column 3 %v 1, got %v %v", translation, col, w)
+	}
+	row, w := m.VecRow(0)
+	if !EqualElem(row, Vec{Y: -1}, tol) || w != translation.X {
+		t.Errorf("want row 0 %v %v, got %v %v", Vec{Y: -1}, translation.X, row, w)
+	}
+}
+
+func TestAzimuthElevation(t *testing.T) {
+	const tol = 1e-5
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 64; i++ {
+		wantAz := (rng.Float64()*2 - 1) * math.Pi
+		wantEl := (rng.Float64() - 0.5) * math.Pi
+		r := 0.1 + rng.Float64()*10
+		v := Vec{
+			X: float32(r * math.Cos(wantEl) * math.Sin(wantAz)),
+			Y: float32(r * math.Sin(wantEl)),
+			Z: float32(r * math.Cos(wantEl) * math.Cos(wantAz)),
+		}
+		az, el := v.AzimuthElevation()
+		if math.Abs(float64(az)-wantAz) > tol || math.Abs(float64(el)-wantEl) > tol {
+			t.Errorf("%v: want az,el=%v,%v, got %v,%v", v, wantAz, wantEl, az, el)
+		}
+	}
+	if az, el := (Vec{Y: -2}).AzimuthElevation(); az != 0 || math.Abs(float64(el)+math.Pi/2) > tol {
+		t.Errorf("down direction: got az,el=%v,%v", az, el)
+	}
+}
+
+func TestSlerpDirection(t *testing.T) {
+	const tol = 1e-5
+	rng := rand.New(rand.NewSource(1))
+	randVec := func() Vec {
+		return Vec{X: float32(rng.NormFloat64()), Y: float32(rng.NormFloat64()), Z: float32(rng.NormFloat64())}
+	}
+	for i := 0; i < 64; i++ {
+		a, b := randVec(), randVec()
+		if i%8 == 0 {
+			b = Scale(-2, a) // Antipodal.
+		}
+		if !EqualElem(SlerpDirection(a, b, 0), Unit(a), tol) || !EqualElem(SlerpDirection(a, b, 1), Unit(b), tol) {
+			t.Fatalf("endpoints not preserved for %v, %v", a, b)
+		}
+		angle := math.Acos(float64(Cos(a, b)))
+		for _, tm := range []float32{0.25, 0.5, 0.75} {
+			got := SlerpDirection(a, b, tm)
+			if math.Abs(float64(Norm(got))-1) > tol {
+				t.Errorf("non-unit result %v", got)
+			}
+			// Constant angular velocity along the great circle.
+			if gotAngle := math.Acos(math.Min(1, float64(Cos(a, got)))); math.Abs(gotAngle-angle*float64(tm)) > 1e-3 {
+				t.Errorf("want angle %v from a, got %v", angle*float64(tm), gotAngle)
+			}
+			if i%8 != 0 && math.Abs(float64(Dot(got, Cross(a, b)))) > 1e-4 {
+				t.Errorf("result %v not on great circle of %v, %v", got, a, b)
+			}
+		}
+	}
+	// Nearly antipodal directions still interpolate along the great circle joining them.
+	a := Vec{X: 1}
+	for _, angle := range []float32{math.Pi - 0.03, math.Pi - 1e-3} {
+		b := Vec{X: float32(math.Cos(float64(angle))), Y: float32(math.Sin(float64(angle)))}
+		if got := SlerpDirection(a, b, 1); !EqualElem(got, b, 1e-4) {
+			t.Errorf("nearly antipodal t=1: want %v, got %v", b, got)
+		}
+		half := angle / 2
+		want := Vec{X: float32(math.Cos(float64(half))), Y: float32(math.Sin(float64(half)))}
+		if got := SlerpDirection(a, b, 0.5); !EqualElem(got, want, 1e-3) {
+			t.Errorf("nearly antipodal t=0.5: want %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSpatialHash(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 500
+	const cellSize = 0.3
+	sh := NewSpatialHash(cellSize)
+	points := make([]Vec, n)
+	bounds := NewBox(-2, -2, -2, 2, 2, 2)
+	for build := 0; build < 2; build++ {
+		sh.Reset()
+		for i := range points {
+			points[i] = bounds.RandomPoint(rng)
+			sh.Insert(i, points[i])
+		}
+		var got []int
+		for q := 0; q < 50; q++ {
+			p := bounds.RandomPoint(rng)
+			radius := float32(rng.Float64())
+			got = sh.AppendQuery(got[:0], p, radius)
+			candidates := make(map[int]bool, len(got))
+			for _, id := range got {
+				if candidates[id] {
+					t.Fatalf("id %d returned twice", id)
+				}
+				candidates[id] = true
+			}
+			for i, pt := range points {
+				if Distance(p, pt) <= radius && !candidates[i] {
+					t.Fatalf("point %d at distance %v within radius %v of %v not returned", i, Distance(p, pt), radius, p)
+				}
+			}
+			if len(got) == n {
+				t.Error("query returned all points, expected spatial pruning")
+			}
+		}
+	}
+	if got := sh.Query(Vec{X: 100}, 1); len(got) != 0 {
+		t.Errorf("expected no ids far from inserted points, got %v", got)
+	}
+	// Huge and infinite queries must terminate and return every point.
+	for _, radius := range []float32{1e6, float32(math.Inf(1))} {
+		if got := sh.Query(Vec{}, radius); len(got) != n {
+			t.Errorf("radius %v: want all %d ids, got %d", radius, n, len(got))
+		}
+	}
+	// Coordinates beyond the int32 cell index range are clamped.
+	sh.Insert(n, Vec{X: 1e30, Y: -1e30, Z: float32(math.Inf(1))})
+	if got := sh.Query(Vec{X: 1e30, Y: -1e30, Z: float32(math.Inf(1))}, 1); len(got) != 1 || got[0] != n {
+		t.Errorf("want id %d for point at clamped cell, got %v", n, got)
+	}
+}
+
+func TestRotationEqual(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 32; i++ {
+		axis := Unit(Vec{X: float32(rng.NormFloat64()), Y: float32(rng.NormFloat64()), Z: float32(rng.NormFloat64())})
+		q := RotationQuat(float32(rng.Float64()*2*math.Pi), axis)
+		a := RotatingMat3(q)
+		b := RotatingMat3(q.Scale(-1)) // Same orientation.
+		if !RotationEqual(a, b, 1e-5) {
+			t.Errorf("q and -q rotations not equal:\n%v\n%v", a, b)
+		}
+		perturbed := MulMat3(RotatingMat3(RotationQuat(1e-2, axis)), a)
+		if RotationEqual(a, perturbed, 1e-3) {
+			t.Errorf("rotations differing by 0.01 radians compared equal with tolerance 1e-3")
+		}
+		if !RotationEqual(a, perturbed, 2e-2) {
+			t.Errorf("rotations differing by 0.01 radians compared unequal with tolerance 2e-2")
+		}
+	}
+}
+
+func TestRotationBetweenVecsQuatStable(t *testing.T) {
+	const tol = 1e-4
+	rng := rand.New(rand.NewSource(1))
+	randVec := func() Vec {
+		return Vec{X: float32(rng.NormFloat64()), Y: float32(rng.NormFloat64()), Z: float32(rng.NormFloat64())}
+	}
+	for i := 0; i < 64; i++ {
+		start, dest := randVec(), randVec()
+		switch i % 4 {
+		case 1:
+			dest = Scale(-3, start) // Exactly opposite.
+		case 2:
+			dest = Add(Scale(-1, start), Scale(1e-3, randVec())) // Nearly opposite.
+		}
+		q := RotationBetweenVecsQuatStable(start, dest)
+		if math.Abs(float64(q.Norm())-1) > tol {
+			t.Errorf("non-unit quaternion %v", q)
+		}
+		if got := q.Rotate(Unit(start)); !EqualElem(got, Unit(dest), tol) {
+			t.Errorf("rotated start %v, want %v", got, Unit(dest))
+		}
+	}
+	// Result varies continuously as dest sweeps past the direction opposite to start.
+	start := Vec{X: 1, Y: 0.2, Z: -0.3}
+	perp := Unit(Cross(start, Vec{Z: 1}))
+	prev := RotationBetweenVecsQuatStable(start, Add(Scale(-1, start), Scale(-0.1, perp)))
+	for i := -99; i <= 100; i++ {
+		dest := Add(Scale(-1, start), Scale(float32(i)*1e-3, perp))
+		q := RotationBetweenVecsQuatStable(start, dest)
+		if math.Abs(float64(q.Dot(prev))) < 0.99 {
+			t.Fatalf("discontinuity at step %d: %v -> %v", i, prev, q)
+		}
+		prev = q
+	}
+}
+
+func TestMat4DecomposeFull(t *testing.T) {
+	const tol = 1e-4
+	rng := rand.New(rand.NewSource(1))
+	randVec := func() Vec {
+		return Vec{X: float32(rng.NormFloat64()), Y: float32(rng.NormFloat64()), Z: float32(rng.NormFloat64())}
+	}
+	for i := 0; i < 64; i++ {
+		translation := randVec()
+		rotation := RotationQuat(float32(rng.Float64()*2*math.Pi), Unit(randVec()))
+		scale := AddScalar(0.5, AbsElem(randVec()))
+		if i%2 == 1 {
+			scale.X = -scale.X // Mirroring transform.
+		}
+		var shear Vec
+		if i%4 >= 2 {
+			shear = Scale(0.5, randVec())
+		}
+		m := ComposeTRSShear(translation, rotation, scale, shear)
+		gotT, gotR, gotS, gotH := m.DecomposeFull()
+		if !EqualElem(gotT, translation, tol) || !EqualElem(gotS, scale, tol) || !EqualElem(gotH, shear, tol) {
+			t.Errorf("want T,S,H=%v,%v,%v, got %v,%v,%v", translation, scale, shear, gotT, gotS, gotH)
+		}
+		if math.Abs(float64(gotR.Dot(rotation.Unit()))) < 1-tol {
+			t.Errorf("want rotation %v, got %v", rotation.Unit(), gotR)
+		}
+		if got := ComposeTRSShear(gotT, gotR, gotS, gotH); !EqualMat4(got, m, tol) {
+			t.Errorf("recomposition mismatch:\n%v\n%v", got, m)
+		}
+		if shear == (Vec{}) {
+			// Without shear ComposeTRS is equivalent.
+			if got := ComposeTRS(m.Decompose()); !EqualMat4(got, m, tol) {
+				t.Errorf("TRS recomposition mismatch:\n%v\n%v", got, m)
+			}
+		}
+	}
+}
+
+func TestCentroidBoundingBox(t *testing.T) {
+	if Centroid(nil) != (Vec{}) || BoundingBox(nil) != (Box{}) {
+		t.Error("want zero values for empty point set")
+	}
+	points := []Vec{{X: 1, Y: -2, Z: 3}, {X: -1, Y: 4, Z: 0}, {X: 3, Y: 1, Z: -3}}
+	wantCentroid := Vec{X: 1, Y: 1, Z: 0}
+	if got := Centroid(points); !got.Equal(wantCentroid, 1e-6) {
+		t.Errorf("want centroid %v, got %v", wantCentroid, got)
+	}
+	wantBox := Box{Min: Vec{X: -1, Y: -2, Z: -3}, Max: Vec{X: 3, Y: 4, Z: 3}}
+	if got := BoundingBox(points); got != wantBox {
+		t.Errorf("want bounding box %v, got %v", wantBox, got)
+	}
+}
+
+func TestMatFromColsRows(t *testing.T) {
+	c0, c1, c2 := Vec{X: 1, Y: 2, Z: 3}, Vec{X: 4, Y: 5, Z: 6}, Vec{X: 7, Y: 8, Z: 9}
+	translation := Vec{X: -1, Y: -2, Z: -3}
+	m3 := Mat3FromCols(c0, c1, c2)
+	if m3.VecCol(0) != c0 || m3.VecCol(1) != c1 || m3.VecCol(2) != c2 {
+		t.Errorf("Mat3FromCols columns mismatch: %v", m3)
+	}
+	m3 = Mat3FromRows(c0, c1, c2)
+	if m3.VecRow(0) != c0 || m3.VecRow(1) != c1 || m3.VecRow(2) != c2 {
+		t.Errorf("Mat3FromRows rows mismatch: %v", m3)
+	}
+	m4 := Mat4FromCols(c0, c1, c2, translation)
+	for j, want := range []Vec{c0, c1, c2, translation} {
+		col, w := m4.VecCol(j)
+		if col != want || (j == 3) != (w == 1) {
+			t.Errorf("Mat4FromCols column %d: want %v, got %v %v", j, want, col, w)
+		}
+	}
+	m4 = Mat4FromRows(c0, c1, c2, translation)
+	for i, want := range []Vec{c0, c1, c2} {
+		row, _ := m4.VecRow(i)
+		if row != want {
+			t.Errorf("Mat4FromRows row %d: want %v, got %v", i, want, row)
+		}
+	}
+	if got := m4.Translation(); got != translation {
+		t.Errorf("Mat4FromRows: want translation %v, got %v", translation, got)
+	}
+}
+
+func TestRotationOrderString(t *testing.T) {
+	for o := XYX; o <= ZXY; o++ {
+		got, err := ParseRotationOrder(o.String())
+		if err != nil {
+			t.Fatal(err)
+		} else if got != o {
+			t.Errorf("round trip of %s: got %s", o, got)
+		}
+	}
+	if got, err := ParseRotationOrder("zyx"); err != nil || got != ZYX {
+		t.Errorf("want ZYX parsed from lower case, got %v, %v", got, err)
+	}
+	if _, err := ParseRotationOrder("XYW"); err == nil {
+		t.Error("expected error parsing invalid rotation order")
+	}
+	if got := RotationOrder(-1).String(); got != "RotationOrder(-1)" {
+		t.Errorf("unexpected string for invalid order: %q", got)
+	}
+}
+
+func TestMat4MulPositions(t *testing.T) {
+	const tol = 1e-5
+	m := ComposeTRS(Vec{X: 1, Y: -2, Z: 3}, RotationQuat(0.7, Unit(Vec{X: 1, Y: 1, Z: 0.5})), Vec{X: 2, Y: 1, Z: 0.5})
+	src := []Vec{{X: 1, Y: 2, Z: 3}, {X: -4, Y: 0, Z: 1}, {}, {X: 0.5, Y: -0.25, Z: 8}}
+	positions := make([]Vec, len(src))
+	m.MulPositions(positions, src)
+	directions := make([]Vec, len(src))
+	m.MulDirections(directions, src)
+	translation := m.Translation()
+	for i, v := range src {
+		if want := m.MulPosition(v); !positions[i].Equal(want, tol) {
+			t.Errorf("position %d: want %v, got %v", i, want, positions[i])
+		}
+		if want := Sub(m.MulPosition(v), translation); !directions[i].Equal(want, tol) {
+			t.Errorf("direction %d: want %v, got %v", i, want, directions[i])
+		}
+	}
+	inplace := append([]Vec{}, src...)
+	m.MulPositions(inplace, inplace)
+	for i := range inplace {
+		if inplace[i] != positions[i] {
+			t.Errorf("in-place position %d: want %v, got %v", i, positions[i], inplace[i])
+		}
+	}
+}
+
+func BenchmarkMat4MulPositions(b *testing.B) {
+	const n = 4096
+	m := ComposeTRS(Vec{X: 1, Y: 2, Z: 3}, RotationQuat(0.5, Vec{Z: 1}), Vec{X: 1, Y: 1, Z: 1})
+	verts := make([]Vec, n)
+	for i := range verts {
+		verts[i] = Vec{X: float32(i), Y: float32(i % 7), Z: float32(i % 13)}
+	}
+	b.SetBytes(n * int64(unsafe.Sizeof(Vec{})))
+	for i := 0; i < b.N; i++ {
+		m.MulPositions(verts, verts)
+	}
+}
+
+func TestShadowMatrix(t *testing.T) {
+	const tol = 1e-4
+	plane := NewPlane(Vec{Y: -1}, Vec{X: 0.1, Y: 1, Z: -0.2})
+	// project applies m to p with w=1 and performs the perspective divide.
+	project := func(m Mat4, p Vec) Vec {
+		row, w := m.VecRow(3)
+		return Scale(1/(Dot(row, p)+w), m.MulPosition(p))
+	}
+	points := []Vec{{X: 1, Y: 2, Z: 3}, {X: -2, Y: 0.5, Z: 0}, {Y: 3}}
+	light := Vec{X: 2, Y: 10, Z: -1}
+	m := ShadowMatrix(plane, light, 1)
+	for _, p := range points {
+		got := project(m, p)
+		if d := plane.SignedDistance(got); math.Abs(float64(d)) > tol {
+			t.Errorf("point light shadow of %v not on plane: distance %v", p, d)
+		}
+		if c := Cross(Sub(p, light), Sub(got, light)); Norm(c) > tol*Norm(Sub(got, light)) {
+			t.Errorf("point light shadow %v of %v not on ray from light", got, p)
+		}
+	}
+	dir := Vec{X: 0.5, Y: 1, Z: 0.25}
+	m = ShadowMatrix(plane, dir, 0)
+	for _, p := range points {
+		got := project(m, p)
+		if d := plane.SignedDistance(got); math.Abs(float64(d)) > tol {
+			t.Errorf("directional shadow of %v not on plane: distance %v", p, d)
+		}
+		if c := Cross(dir, Sub(got, p)); Norm(c) > tol*Norm(Sub(got, p)) {
+			t.Errorf("directional shadow %v of %v not along light direction", got, p)
+		}
+	}
+}
+
+func TestEqualMat4NaN(t *testing.T) {
+	m := ComposeTRS(Vec{X: 1, Y: 2, Z: 3}, RotationQuat(0.3, Vec{Z: 1}), Vec{X: 1e3, Y: 1e3, Z: 1e3})
+	singular := ScalingMat4(Vec{X: 1, Y: 0, Z: 1}).Inverse()
+	if EqualMat4(singular, singular, 1) || EqualMat4Rel(singular, singular, 1) {
+		t.Error("matrices with NaN elements must not compare equal")
+	}
+	if !EqualMat4(m, m, 0) || !EqualMat4Rel(m, m, 0) {
+		t.Error("matrix must compare equal to itself")
+	}
+	perturbed := MulMat4(ScalingMat4(Vec{X: 1 + 1e-5, Y: 1, Z: 1}), m)
+	if EqualMat4(m, perturbed, 1e-6) {
+		t.Error("absolute comparison should fail for large elements")
+	}
+	if !EqualMat4Rel(m, perturbed, 1e-4) {
+		t.Error("relative comparison should pass for large elements")
+	}
+	nan := singular.Array()
+	if EqualMat3(IdentityMat3(), NewMat3(nan[:]), 1) {
+		t.Error("3x3 matrices with NaN elements must not compare equal")
+	}
+}
+
+func TestQuatRotateSlice(t *testing.T) {
+	const tol = 1e-5
+	rng := rand.New(rand.NewSource(1))
+	src := make([]Vec, 16)
+	for i := range src {
+		src[i] = Vec{X: float32(rng.Float64()*8 - 4), Y: float32(rng.Float64()*8 - 4), Z: float32(rng.Float64()*8 - 4)}
+	}
+	for _, q := range []Quat{
+		RotationQuat(1.2, Unit(Vec{X: 1, Y: -2, Z: 0.5})),
+		{W: 1},
+		{W: 0.5, I: 1, J: -0.25, K: 2}, // Non-unit quaternion.
+	} {
+		dst := make([]Vec, len(src))
+		q.RotateSlice(dst, src)
+		for i, v := range src {
+			if want := q.Rotate(v); !dst[i].Equal(want, tol*Norm(want)+tol) {
+				t.Errorf("%v rotating %v: want %v, got %v", q, v, want, dst[i])
+			}
+		}
+		inplace := append([]Vec{}, src...)
+		q.RotateSlice(inplace, inplace)
+		if !slices.Equal(inplace, dst) {
+			t.Errorf("%v: in-place rotation differs", q)
+		}
+	}
+}
+
+func TestBoxFaces(t *testing.T) {
+	const tol = 1e-6
+	box := Box{Min: Vec{X: -1, Y: 0, Z: 2}, Max: Vec{X: 3, Y: 4, Z: 8}}
+	if got := box.FaceCenter(0, true); got != (Vec{X: 3, Y: 2, Z: 5}) {
+		t.Errorf("unexpected +X face center %v", got)
+	}
+	if got := box.FaceCenter(2, false); got != (Vec{X: 1, Y: 2, Z: 2}) {
+		t.Errorf("unexpected -Z face center %v", got)
+	}
+	inside := box.Center()
+	for i, face := range box.Faces() {
+		axis, positive := i/2, i%2 == 1
+		center := box.FaceCenter(axis, positive)
+		if d := face.SignedDistance(center); math.Abs(float64(d)) > tol {
+			t.Errorf("face %d: center not on plane, distance %v", i, d)
+		}
+		if face.Normal != box.FaceNormal(axis, positive) {
+			t.Errorf("face %d: plane normal %v does not match face normal", i, face.Normal)
+		}
+		if Dot(face.Normal, Sub(center, inside)) <= 0 {
+			t.Errorf("face %d: normal %v does not point outwards", i, face.Normal)
+		}
+		if face.SignedDistance(inside) >= 0 {
+			t.Errorf("face %d: box center has non-negative distance", i)
+		}
+	}
+}
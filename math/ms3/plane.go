@@ -0,0 +1,30 @@
+package ms3
+
+// Plane is an infinite plane in 3D space formed by the points p
+// which satisfy the equation Dot(Normal, p) + D = 0.
+// Points on the side of the plane Normal points towards have positive signed distance.
+type Plane struct {
+	Normal Vec
+	D      float32
+}
+
+// NewPlane returns the plane which contains point and is perpendicular to normal.
+// The normal is normalized so that [Plane.SignedDistance] returns Euclidean distances.
+func NewPlane(point, normal Vec) Plane {
+	normal = Unit(normal)
+	return Plane{Normal: normal, D: -Dot(normal, point)}
+}
+
+// SignedDistance returns the distance from p to the plane scaled by the
+// norm of the plane's Normal. The result is positive if p is on the side
+// of the plane the Normal points towards and negative otherwise.
+func (pl Plane) SignedDistance(p Vec) float32 {
+	return Dot(pl.Normal, p) + pl.D
+}
+
+// planeFromCoefs returns the normalized plane with equation a*x + b*y + c*z + d = 0.
+func planeFromCoefs(a, b, c, d float32) Plane {
+	normal := Vec{X: a, Y: b, Z: c}
+	inorm := 1 / Norm(normal)
+	return Plane{Normal: Scale(inorm, normal), D: d * inorm}
+}
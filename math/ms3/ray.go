@@ -0,0 +1,85 @@
+package ms3
+
+import (
+	math "github.com/chewxy/math32"
+)
+
+// Ray is a half-line starting at Origin and extending infinitely in direction Dir.
+// Dir need not be normalized, in which case distances along the ray are
+// measured in multiples of Dir's length.
+type Ray struct {
+	Origin Vec
+	Dir    Vec
+}
+
+// At returns the point along the ray at parameter t: Origin + t*Dir.
+func (r Ray) At(t float32) Vec {
+	return Add(r.Origin, Scale(t, r.Dir))
+}
+
+// IntersectBox returns the ray parameters at which the ray enters and exits b.
+// If the ray origin is within the box tmin is negative. ok is false if the ray
+// does not intersect the box.
+func (r Ray) IntersectBox(b Box) (tmin, tmax float32, ok bool) {
+	tmin, tmax, _, _, ok = r.slabs(b)
+	return tmin, tmax, ok
+}
+
+// IntersectBoxNormal returns the ray parameter and face normal of the first
+// face of b the ray crosses. If the ray origin is within the box the exit face
+// is returned. The returned normal is axis aligned and always faces against the
+// ray direction, i.e: the normal is the outward face normal for an entering ray
+// and the inward face normal for a ray exiting the box.
+// ok is false if the ray does not intersect the box.
+func (r Ray) IntersectBoxNormal(b Box) (t float32, normal Vec, ok bool) {
+	tmin, tmax, minAxis, maxAxis, ok := r.slabs(b)
+	if !ok {
+		return 0, Vec{}, false
+	}
+	axis := minAxis
+	t = tmin
+	if tmin < 0 {
+		axis = maxAxis
+		t = tmax
+	}
+	dir := [3]float32{r.Dir.X, r.Dir.Y, r.Dir.Z}
+	n := [3]float32{}
+	n[axis] = -math.Copysign(1, dir[axis])
+	return t, Vec{X: n[0], Y: n[1], Z: n[2]}, true
+}
+
+// slabs performs the slab intersection test and returns the entry and exit
+// ray parameters along with the axis of the slab which produced each.
+func (r Ray) slabs(b Box) (tmin, tmax float32, minAxis, maxAxis int, ok bool) {
+	origin := [3]float32{r.Origin.X, r.Origin.Y, r.Origin.Z}
+	dir := [3]float32{r.Dir.X, r.Dir.Y, r.Dir.Z}
+	bmin := [3]float32{b.Min.X, b.Min.Y, b.Min.Z}
+	bmax := [3]float32{b.Max.X, b.Max.Y, b.Max.Z}
+	tmin, tmax = math.Inf(-1), math.Inf(1)
+	minAxis, maxAxis = -1, -1
+	for axis := 0; axis < 3; axis++ {
+		if dir[axis] == 0 {
+			if origin[axis] < bmin[axis] || origin[axis] > bmax[axis] {
+				return 0, 0, -1, -1, false // Parallel to slab and outside it.
+			}
+			continue
+		}
+		inv := 1 / dir[axis]
+		t0 := (bmin[axis] - origin[axis]) * inv
+		t1 := (bmax[axis] - origin[axis]) * inv
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t0 > tmin {
+			tmin, minAxis = t0, axis
+		}
+		if t1 < tmax {
+			tmax, maxAxis = t1, axis
+		}
+	}
+	if minAxis < 0 || tmin > tmax || tmax < 0 {
+		// Zero direction or missed box or box behind ray.
+		return 0, 0, -1, -1, false
+	}
+	return tmin, tmax, minAxis, maxAxis, true
+}
@@ -0,0 +1,117 @@
+package ms3
+
+import (
+	math "github.com/chewxy/math32"
+)
+
+// SpatialHash is a uniform grid of cells which accelerates neighbor queries of points.
+// Memory is retained across calls to [SpatialHash.Reset] so that the hash
+// may be rebuilt every frame without allocating.
+type SpatialHash struct {
+	cellSize float32
+	cells    map[spatialCell]int // Maps cell to its index in buckets.
+	buckets  [][]int
+}
+
+type spatialCell struct {
+	x, y, z int32
+}
+
+// NewSpatialHash returns an empty SpatialHash with cubic cells of side cellSize.
+// NewSpatialHash panics if cellSize is not positive.
+func NewSpatialHash(cellSize float32) *SpatialHash {
+	if !(cellSize > 0) {
+		panic("non-positive spatial hash cell size")
+	}
+	return &SpatialHash{
+		cellSize: cellSize,
+		cells:    make(map[spatialCell]int),
+	}
+}
+
+// Insert adds id at position p to the hash. An id may be inserted several times.
+func (sh *SpatialHash) Insert(id int, p Vec) {
+	cell := sh.cell(p)
+	idx, ok := sh.cells[cell]
+	if !ok {
+		idx = len(sh.buckets)
+		if idx < cap(sh.buckets) {
+			// Reuse bucket memory of a previous build.
+			sh.buckets = sh.buckets[:idx+1]
+			sh.buckets[idx] = sh.buckets[idx][:0]
+		} else {
+			sh.buckets = append(sh.buckets, nil)
+		}
+		sh.cells[cell] = idx
+	}
+	sh.buckets[idx] = append(sh.buckets[idx], id)
+}
+
+// Query returns the ids inserted in the cells overlapped by the sphere of
+// center p and given radius. The result is a superset of the ids within radius of p.
+// Coordinates beyond the int32 range of cell indices are clamped to the outermost cells.
+func (sh *SpatialHash) Query(p Vec, radius float32) []int {
+	return sh.AppendQuery(nil, p, radius)
+}
+
+// AppendQuery is like [SpatialHash.Query] but appends the ids to dst and returns the result.
+func (sh *SpatialHash) AppendQuery(dst []int, p Vec, radius float32) []int {
+	r := elem(math.Abs(radius))
+	lo := sh.cell(Sub(p, r))
+	hi := sh.cell(Add(p, r))
+	// Spans are computed in float64 since their product overflows integers for huge queries.
+	nx := float64(hi.x) - float64(lo.x) + 1
+	ny := float64(hi.y) - float64(lo.y) + 1
+	nz := float64(hi.z) - float64(lo.z) + 1
+	if nx*ny*nz > float64(len(sh.cells)) {
+		// Query covers more cells than are occupied, check occupied cells instead.
+		for cell, idx := range sh.cells {
+			if cell.x >= lo.x && cell.x <= hi.x && cell.y >= lo.y && cell.y <= hi.y && cell.z >= lo.z && cell.z <= hi.z {
+				dst = append(dst, sh.buckets[idx]...)
+			}
+		}
+		return dst
+	}
+	// int64 loop variables do not overflow at the int32 cell index limits.
+	for z := int64(lo.z); z <= int64(hi.z); z++ {
+		for y := int64(lo.y); y <= int64(hi.y); y++ {
+			for x := int64(lo.x); x <= int64(hi.x); x++ {
+				idx, ok := sh.cells[spatialCell{x: int32(x), y: int32(y), z: int32(z)}]
+				if ok {
+					dst = append(dst, sh.buckets[idx]...)
+				}
+			}
+		}
+	}
+	return dst
+}
+
+// Reset removes all ids from the hash while retaining allocated memory.
+func (sh *SpatialHash) Reset() {
+	clear(sh.cells)
+	sh.buckets = sh.buckets[:0]
+}
+
+// CellSize returns the side length of the hash's cells.
+func (sh *SpatialHash) CellSize() float32 { return sh.cellSize }
+
+func (sh *SpatialHash) cell(p Vec) spatialCell {
+	inv := 1 / sh.cellSize
+	return spatialCell{
+		x: cellIndex(p.X * inv),
+		y: cellIndex(p.Y * inv),
+		z: cellIndex(p.Z * inv),
+	}
+}
+
+// cellIndex returns the cell index of the scaled coordinate v clamped
+// to the int32 range. NaN coordinates map to the lowest index.
+func cellIndex(v float32) int32 {
+	f := math.Floor(v)
+	if !(f > math.MinInt32) {
+		return math.MinInt32
+	} else if f >= math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(f)
+}
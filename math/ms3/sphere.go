@@ -0,0 +1,18 @@
+package ms3
+
+// Sphere is a sphere in 3D space defined by its center and radius.
+type Sphere struct {
+	Center Vec
+	Radius float32
+}
+
+// Box returns the axis aligned bounding box of the sphere.
+func (s Sphere) Box() Box {
+	r := Vec{X: s.Radius, Y: s.Radius, Z: s.Radius}
+	return Box{Min: Sub(s.Center, r), Max: Add(s.Center, r)}
+}
+
+// Contains returns true if p is within the sphere or on its surface.
+func (s Sphere) Contains(p Vec) bool {
+	return DistanceSquared(s.Center, p) <= s.Radius*s.Radius
+}
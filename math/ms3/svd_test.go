@@ -32,6 +32,54 @@ func BenchmarkSVDOriginal(b *testing.B) {
 	}
 }
 
+func benchMats(n int) []ms3.Mat3 {
+	rng := rand.New(rand.NewSource(1))
+	mats := make([]ms3.Mat3, n)
+	for i := range mats {
+		var m [9]float32
+		for j := range m {
+			m[j] = float32(rng.Float64())
+		}
+		mats[i] = ms3.NewMat3(m[:])
+	}
+	return mats
+}
+
+func BenchmarkBatchSVD(b *testing.B) {
+	mats := benchMats(256)
+	u := make([]ms3.Mat3, len(mats))
+	s := make([]ms3.Mat3, len(mats))
+	v := make([]ms3.Mat3, len(mats))
+	b.Run("USV", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ms3.BatchSVD(mats, u, s, v)
+		}
+	})
+	b.Run("V", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ms3.BatchSVD(mats, nil, nil, v)
+		}
+	})
+}
+
+func BenchmarkPolarRotation(b *testing.B) {
+	mats := benchMats(256)
+	dst := make([]ms3.Mat3, len(mats))
+	b.Run("PolarRotation", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ms3.BatchPolarRotation(dst, mats)
+		}
+	})
+	b.Run("FromSVD", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j, a := range mats {
+				U, _, V := a.SVD()
+				dst[j] = ms3.MulMat3(U, V.Transpose())
+			}
+		}
+	})
+}
+
 func TestSVD(t *testing.T) {
 	const tol = 1e-5
 	rng := rand.New(rand.NewSource(1))
@@ -74,33 +122,9 @@ const (
 	epsilon = 1e-6
 )
 
-// rsqrt computes an approximate reciprocal square root of x.
-func rsqrt(x float32) float32 {
-	return 1. / math.Sqrt(x)
-	// xhalf := 0.5 * x
-	// i := math.Float32bits(x)
-	// i = 0x5f375a82 - (i >> 1)
-	// x = math.Float32frombits(i)
-	// x = x * (1.5 - xhalf*x*x)
-	// return x
-}
-
-// rsqrt1 computes a more accurate reciprocal square root of x.
-func rsqrt1(x float32) float32 {
-	return 1. / math.Sqrt(x)
-	// xhalf := 0.5 * x
-	// i := math.Float32bits(x)
-	// i = 0x5f37599e - (i >> 1)
-	// x = math.Float32frombits(i)
-	// x = x * (1.5 - xhalf*x*x)
-	// x = x * (1.5 - xhalf*x*x)
-	// return x
-}
-
-// accurateSqrt computes the square root of x using rsqrt1.
+// accurateSqrt computes the square root of x.
 func accurateSqrt(x float32) float32 {
 	return math.Sqrt(x)
-	// return x * rsqrt1(x)
 }
 
 // condSwap swaps X and Y if condition c is true.
@@ -200,7 +224,7 @@ func approximateGivensQuaternion(a11, a12, a22 float32, ch, sh *float32) {
 	*ch = 2 * (a11 - a22)
 	*sh = a12
 	b := gamma*(*sh)*(*sh) < (*ch)*(*ch)
-	w := rsqrt((*ch)*(*ch) + (*sh)*(*sh))
+	w := 1. / math.Sqrt((*ch)*(*ch)+(*sh)*(*sh))
 	if b {
 		*ch = w * (*ch)
 		*sh = w * (*sh)
@@ -346,7 +370,7 @@ func QRGivensQuaternion(a1, a2 float32, ch, sh *float32) {
 	*ch = math.Abs(a1) + math.Max(rho, eps)
 	b := a1 < 0
 	condSwap(b, sh, ch)
-	w := rsqrt(*ch**ch + *sh**sh)
+	w := 1. / math.Sqrt(*ch**ch+*sh**sh)
 	*ch *= w
 	*sh *= w
 }
@@ -0,0 +1,55 @@
+package ms3
+
+// Transform is a 3D transformation composed of a scaling followed
+// by a rotation followed by a translation. The zero value is not a valid
+// transform, use [IdentityTransform] to create a transform that does nothing.
+type Transform struct {
+	Position Vec
+	Rotation Quat
+	Scale    Vec
+}
+
+// IdentityTransform returns the Transform which leaves points unchanged.
+func IdentityTransform() Transform {
+	return Transform{Rotation: QuatIdent(), Scale: Vec{X: 1, Y: 1, Z: 1}}
+}
+
+// Matrix returns the 4x4 matrix equivalent of the transform. See [ComposeTRS].
+func (t Transform) Matrix() Mat4 {
+	return ComposeTRS(t.Position, t.Rotation, t.Scale)
+}
+
+// TransformPoint applies the scaling, rotation and translation to p.
+func (t Transform) TransformPoint(p Vec) Vec {
+	return Add(t.Position, t.Rotation.Unit().Rotate(MulElem(t.Scale, p)))
+}
+
+// TransformDirection applies only the rotation to the direction d.
+// The length of d is preserved.
+func (t Transform) TransformDirection(d Vec) Vec {
+	return t.Rotation.Unit().Rotate(d)
+}
+
+// Inverse returns the transform which undoes t. The result
+// is exact only if t's scale is uniform, i.e: Scale.X == Scale.Y == Scale.Z.
+func (t Transform) Inverse() Transform {
+	invRot := t.Rotation.Unit().Conjugate()
+	invScale := DivElem(Vec{X: 1, Y: 1, Z: 1}, t.Scale)
+	return Transform{
+		Position: MulElem(invScale, invRot.Rotate(Scale(-1, t.Position))),
+		Rotation: invRot,
+		Scale:    invScale,
+	}
+}
+
+// Mul returns the composition of t and child, which is the transform that
+// first applies child and then t. This is how a scene graph child's transform
+// relative to its parent is converted to world space. The result is exact
+// only if t's scale is uniform or child has no rotation.
+func (t Transform) Mul(child Transform) Transform {
+	return Transform{
+		Position: t.TransformPoint(child.Position),
+		Rotation: t.Rotation.Unit().Mul(child.Rotation.Unit()),
+		Scale:    MulElem(t.Scale, child.Scale),
+	}
+}
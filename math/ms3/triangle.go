@@ -1,6 +1,9 @@
 package ms3
 
 import (
+	"math/rand"
+	"slices"
+
 	math "github.com/chewxy/math32"
 )
 
@@ -27,6 +30,21 @@ func (t Triangle) Normal() Vec {
 	return Cross(s1, s2)
 }
 
+// FlipWinding returns the triangle with reversed vertex ordering,
+// which inverts the direction of its normal.
+func (t Triangle) FlipWinding() Triangle {
+	return Triangle{t[0], t[2], t[1]}
+}
+
+// FaceToward returns the triangle with its winding ordered
+// such that its normal points toward p.
+func (t Triangle) FaceToward(p Vec) Triangle {
+	if Dot(t.Normal(), Sub(p, t[0])) < 0 {
+		return t.FlipWinding()
+	}
+	return t
+}
+
 // IsDegenerate returns true if all of triangle's vertices are
 // within tol distance of its longest side.
 func (t Triangle) IsDegenerate(tol float32) bool {
@@ -68,10 +86,50 @@ func (t Triangle) Area() float32 {
 	return math.Sqrt(A) / 4
 }
 
+// RandomPoint returns a uniformly distributed random point within the triangle.
+func (t Triangle) RandomPoint(rng *rand.Rand) Vec {
+	// Barycentric coordinates (1-sqrt(r1), sqrt(r1)*(1-r2), sqrt(r1)*r2) are
+	// uniformly distributed over the triangle's area.
+	r1 := math.Sqrt(float32(rng.Float64()))
+	r2 := float32(rng.Float64())
+	p := Scale(1-r1, t[0])
+	p = Add(p, Scale(r1*(1-r2), t[1]))
+	return Add(p, Scale(r1*r2, t[2]))
+}
+
+// SampleMeshSurface returns n uniformly distributed random points on the surface
+// formed by tris. Triangles are chosen with probability proportional to their area.
+// SampleMeshSurface returns nil if n is not positive or the total area of tris is zero.
+func SampleMeshSurface(tris []Triangle, n int, rng *rand.Rand) []Vec {
+	if n <= 0 {
+		return nil
+	}
+	cumulative := make([]float32, len(tris))
+	var total float32
+	for i, tri := range tris {
+		area := tri.Area()
+		if area > 0 { // Guards against NaN areas of degenerate triangles.
+			total += area
+		}
+		cumulative[i] = total
+	}
+	if total == 0 {
+		return nil
+	}
+	points := make([]Vec, n)
+	for i := range points {
+		idx, _ := slices.BinarySearch(cumulative, total*float32(rng.Float64()))
+		idx = min(idx, len(tris)-1)
+		points[i] = tris[idx].RandomPoint(rng)
+	}
+	return points
+}
+
 // Sort performs the sort-3 algorithm and returns
 // l1, l2, l3 such that l1 ≤ l2 ≤ l3.
 func Sort(a, b, c float32) (l1, l2, l3 float32) {
 	// sort-3
+	l1, l2, l3 = a, b, c
 	if l2 < l1 {
 		l1, l2 = l2, l1
 	}
@@ -120,3 +178,67 @@ func (l Line) Distance(p Vec) float32 {
 	num := Norm(Cross(Sub(p, l[0]), Sub(p, l[1])))
 	return num / Norm(Sub(l[1], l[0]))
 }
+
+// OrientTriangles flips triangles in-place so that the windings of triangles
+// sharing an edge are consistent, that is to say adjacent triangles traverse
+// their shared edge in opposite directions. Each connected set of triangles is then
+// flipped as a whole so that its normals point away from reference on average.
+// For closed meshes reference is usually a point inside the mesh such as its centroid.
+// Triangles are considered adjacent only if they share exactly equal vertices.
+func OrientTriangles(tris []Triangle, reference Vec) {
+	edgeTris := make(map[[2]Vec][]int, 3*len(tris)/2)
+	for i, tri := range tris {
+		for k := range tri {
+			key := edgeKey(tri[k], tri[(k+1)%3])
+			edgeTris[key] = append(edgeTris[key], i)
+		}
+	}
+	visited := make([]bool, len(tris))
+	var component []int
+	for start := range tris {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		component = append(component[:0], start)
+		// Breadth first propagation of start's winding. component doubles as the queue.
+		for next := 0; next < len(component); next++ {
+			tri := tris[component[next]]
+			for k := range tri {
+				a, b := tri[k], tri[(k+1)%3]
+				for _, j := range edgeTris[edgeKey(a, b)] {
+					if visited[j] {
+						continue
+					}
+					visited[j] = true
+					if tris[j].hasDirectedEdge(a, b) {
+						tris[j] = tris[j].FlipWinding() // Neighbor must traverse edge as b->a.
+					}
+					component = append(component, j)
+				}
+			}
+		}
+		var outward float32
+		for _, i := range component {
+			outward += Dot(tris[i].Normal(), Sub(tris[i].Centroid(), reference))
+		}
+		if outward < 0 {
+			for _, i := range component {
+				tris[i] = tris[i].FlipWinding()
+			}
+		}
+	}
+}
+
+// hasDirectedEdge returns true if the triangle traverses the edge from a to b.
+func (t Triangle) hasDirectedEdge(a, b Vec) bool {
+	return (t[0] == a && t[1] == b) || (t[1] == a && t[2] == b) || (t[2] == a && t[0] == b)
+}
+
+// edgeKey returns a key identifying the undirected edge between a and b.
+func edgeKey(a, b Vec) [2]Vec {
+	if a.X < b.X || (a.X == b.X && (a.Y < b.Y || (a.Y == b.Y && a.Z < b.Z))) {
+		return [2]Vec{a, b}
+	}
+	return [2]Vec{b, a}
+}
@@ -32,6 +32,12 @@ func (a Vec) AllNonzero() bool {
 	return a.X != 0 && a.Y != 0 && a.Z != 0
 }
 
+// Equal returns true if a and b are within tol of eachother for each component.
+// It is the method form of [EqualElem].
+func (a Vec) Equal(b Vec, tol float32) bool {
+	return EqualElem(a, b, tol)
+}
+
 // Add returns the vector sum of p and q.
 func Add(p, q Vec) Vec {
 	return Vec{
@@ -96,6 +102,19 @@ func Norm2(p Vec) float32 {
 	return p.X*p.X + p.Y*p.Y + p.Z*p.Z
 }
 
+// Distance returns the Euclidean distance between p and q
+//
+//	|p-q| = sqrt((p_x-q_x)^2 + (p_y-q_y)^2 + (p_z-q_z)^2).
+func Distance(p, q Vec) float32 {
+	return Norm(Sub(p, q))
+}
+
+// DistanceSquared returns the Euclidean squared distance between p and q.
+// It is cheaper than [Distance] and is suitable for comparing distances.
+func DistanceSquared(p, q Vec) float32 {
+	return Norm2(Sub(p, q))
+}
+
 // Unit returns the unit vector colinear to p.
 // Unit returns {NaN,NaN,NaN} for the zero vector.
 func Unit(p Vec) Vec {
@@ -110,6 +129,45 @@ func Cos(p, q Vec) float32 {
 	return Dot(p, q) / (Norm(p) * Norm(q))
 }
 
+// AzimuthElevation returns the spherical angles of the direction of v with +Y as up.
+// The azimuth is the angle in (-π, π] of the rotation about +Y which takes +Z to
+// the projection of v onto the XZ plane and the elevation is the angle in [-π/2, π/2]
+// between v and the XZ plane. The azimuth of a vertical direction is 0.
+func (v Vec) AzimuthElevation() (az, el float32) {
+	horizontal := math.Hypot(v.X, v.Z)
+	return math.Atan2(v.X, v.Z), math.Atan2(v.Y, horizontal)
+}
+
+// SlerpDirection spherically interpolates between the directions a and b along
+// the great circle joining them and returns a unit vector. t=0 returns Unit(a) and t=1 returns Unit(b).
+// Antipodal directions have no unique great circle joining them
+// so an arbitrary one perpendicular to a is chosen.
+func SlerpDirection(a, b Vec, t float32) Vec {
+	a, b = Unit(a), Unit(b)
+	dot := Dot(a, b)
+	const epsilon = 0.9995
+	if dot > epsilon {
+		// Directions too close, linearly interpolate and normalize the result.
+		return Unit(InterpElem(a, b, elem(t)))
+	}
+	var rel Vec
+	sum := Norm(Add(a, b))
+	if sum < 1e-6 {
+		// Antipodal directions, guess a great circle perpendicular to a.
+		rel = Cross(Vec{X: 1}, a)
+		if Norm2(rel) < 1e-3 {
+			rel = Cross(Vec{Y: 1}, a)
+		}
+		rel = Unit(rel)
+	} else {
+		rel = Unit(Sub(b, Scale(dot, a)))
+	}
+	// Angle between a and b, well conditioned for nearly parallel and nearly antipodal directions.
+	theta := 2 * math.Atan2(Norm(Sub(a, b)), sum) * t
+	s, c := math.Sincos(theta)
+	return Add(Scale(c, a), Scale(s, rel))
+}
+
 // Divergence returns the divergence of the vector field at the point p,
 // approximated using finite differences with the given step sizes.
 func Divergence(p, step Vec, field func(Vec) Vec) float32 {
@@ -153,6 +211,19 @@ func MaxElem(a, b Vec) Vec {
 	}
 }
 
+// Centroid returns the arithmetic mean of points. Centroid returns
+// the zero Vec if points is empty.
+func Centroid(points []Vec) Vec {
+	if len(points) == 0 {
+		return Vec{}
+	}
+	var sum Vec
+	for _, p := range points {
+		sum = Add(sum, p)
+	}
+	return Scale(1/float32(len(points)), sum)
+}
+
 // AbsElem returns the vector with components set to their absolute value.
 func AbsElem(a Vec) Vec {
 	return Vec{
@@ -246,8 +317,50 @@ func InterpElem(x, y, a Vec) Vec {
 	return Vec{X: ms1.Interp(x.X, y.X, a.X), Y: ms1.Interp(x.Y, y.Y, a.Y), Z: ms1.Interp(x.Z, y.Z, a.Z)}
 }
 
+// Damp moves current towards target with frame rate independent exponential
+// decay. See [ms1.Damp].
+func Damp(current, target Vec, smoothing, dt float32) Vec {
+	return Add(target, Scale(math.Exp(-smoothing*dt), Sub(current, target)))
+}
+
 // SmoothStepElem performs element-wise smooth cubic hermite
 // interpolation between 0 and 1 when e0 < x < e1.
 func SmoothStepElem(e0, e1, x Vec) Vec {
 	return Vec{X: ms1.SmoothStep(e0.X, e1.X, x.X), Y: ms1.SmoothStep(e0.Y, e1.Y, x.Y), Z: ms1.SmoothStep(e0.Z, e1.Z, x.Z)}
 }
+
+// FlattenVecs appends the X, Y and Z components of each vector in vs to dst
+// and returns the result. The result is tightly packed, 3 floats per vector
+// with no padding, which is the usual layout for vertex attributes.
+func FlattenVecs(dst []float32, vs []Vec) []float32 {
+	for _, v := range vs {
+		dst = append(dst, v.X, v.Y, v.Z)
+	}
+	return dst
+}
+
+// AppendVecsFromFloats appends vectors read from tightly packed X, Y, Z
+// components in floats to dst and returns the result. It is the inverse of [FlattenVecs].
+// AppendVecsFromFloats panics if the length of floats is not a multiple of 3.
+func AppendVecsFromFloats(dst []Vec, floats []float32) []Vec {
+	if len(floats)%3 != 0 {
+		panic("floats length not multiple of 3")
+	}
+	for i := 0; i < len(floats); i += 3 {
+		dst = append(dst, Vec{X: floats[i], Y: floats[i+1], Z: floats[i+2]})
+	}
+	return dst
+}
+
+// Hash combines the seeds using [ms1.Hash]. It matches the following GLSL function:
+//
+//	uint hash(uvec3 seed) { return hash(seed.x + hash(seed.y + hash(seed.z))); }
+func Hash(x, y, z uint32) uint32 {
+	return ms1.Hash(x + ms1.Hash(y+ms1.Hash(z)))
+}
+
+// HashToFloat returns a pseudo random number in [0,1) derived from [Hash] of the seeds.
+// See [ms1.HashToFloat] for the matching GLSL function.
+func HashToFloat(x, y, z uint32) float32 {
+	return float32(Hash(x, y, z)>>8) * (1.0 / 16777216.0)
+}
@@ -0,0 +1,209 @@
+//go:build !tinygo && cgo
+
+package glgl_test
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/soypat/glgl/v4.6-core/glgl"
+)
+
+func TestBufferDeleteZeroValue(t *testing.T) {
+	// Zero value buffers perform no GL calls on Delete so no context is needed.
+	var vbo glgl.VertexBuffer
+	var ibo glgl.IndexBuffer
+	var ssbo glgl.ShaderStorageBuffer
+	var ubo glgl.UniformBuffer
+	vbo.Delete()
+	ibo.Delete()
+	ssbo.Delete()
+	ubo.Delete()
+}
+
+func TestBufferEmptyData(t *testing.T) {
+	// Empty data is rejected before any GL calls so no context is needed.
+	if _, err := glgl.NewVertexBuffer[float32](glgl.StaticDraw, nil); err == nil {
+		t.Error("expected error creating vertex buffer from nil data")
+	}
+	if _, err := glgl.NewVertexBuffer(glgl.StaticDraw, []float32{}); err == nil {
+		t.Error("expected error creating vertex buffer from empty data")
+	}
+	if _, err := glgl.NewIndexBuffer(nil); err == nil {
+		t.Error("expected error creating index buffer from nil data")
+	}
+	if _, err := glgl.NewBuffer[uint32](gl.ARRAY_BUFFER, glgl.StaticDraw, nil); err == nil {
+		t.Error("expected error creating buffer from nil data")
+	}
+}
+
+func TestBufferDoubleDelete(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	vbo, err := glgl.NewVertexBuffer(glgl.StaticDraw, []float32{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ibo, err := glgl.NewIndexBuffer([]uint32{0, 1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ssbo, err := glgl.NewShaderStorageBuffer([]float32{1, 2, 3}, glgl.ShaderStorageBufferConfig{Usage: glgl.ReadOrWrite})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		vbo.Delete()
+		ibo.Delete()
+		ssbo.Delete()
+		if err := glgl.Err(); err != nil {
+			t.Fatalf("delete #%d: %v", i+1, err)
+		}
+	}
+	if vbo.ID() != 0 {
+		t.Error("vertex buffer id not zeroed after delete")
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	buf, err := glgl.NewBuffer(gl.ARRAY_BUFFER, glgl.DynamicDraw, []float32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.Delete()
+	if buf.Len() != 4 {
+		t.Fatalf("want 4 elements, got %d", buf.Len())
+	}
+	err = buf.Update(2, []float32{30, 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]float32, 3)
+	err = buf.Get(got, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float32{2, 30, 40}; !slices.Equal(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	mapped, err := buf.Map(0, 4, glgl.ReadOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float32{1, 2, 30, 40}; !slices.Equal(mapped, want) {
+		t.Errorf("mapped want %v, got %v", want, mapped)
+	}
+	err = buf.Unmap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Out of bounds access must be caught before calling the GL.
+	if err = buf.Update(3, []float32{1, 2}); err == nil {
+		t.Error("expected out of bounds update error")
+	}
+	if err = buf.Get(make([]float32, 5), 0); err == nil {
+		t.Error("expected out of bounds get error")
+	}
+	if _, err = buf.Map(-1, 1, glgl.ReadOnly); err == nil {
+		t.Error("expected negative offset map error")
+	}
+}
+
+func initTestWindow(t *testing.T) (terminate func()) {
+	t.Helper()
+	runtime.LockOSThread()
+	_, term, err := glgl.InitWithCurrentWindow33(glgl.WindowConfig{
+		Title:      "glgl test",
+		Version:    [2]int{4, 6},
+		Width:      1,
+		Height:     1,
+		HideWindow: true,
+	})
+	if err != nil {
+		runtime.UnlockOSThread()
+		t.Skip(err)
+	}
+	return func() {
+		term()
+		runtime.UnlockOSThread()
+	}
+}
+
+func TestUniformBuffer(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	ss, err := glgl.ParseCombined(strings.NewReader(`#shader compute
+#version 430
+layout(local_size_x = 1, local_size_y = 1, local_size_z = 1) in;
+layout(std430, binding = 0) buffer Output {
+	vec4 data[];
+};
+layout(std140) uniform Params {
+	vec4 scale;
+	vec4 offset;
+};
+void main() {
+	data[0] = scale * vec4(1.0, 2.0, 3.0, 4.0) + offset;
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := glgl.CompileProgram(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer prog.Delete()
+	prog.Bind()
+	const base = 2
+	if err = prog.UniformBlockBinding("Params\x00", base); err != nil {
+		t.Fatal(err)
+	}
+	if err = prog.UniformBlockBinding("Missing\x00", base); err == nil {
+		t.Error("expected error binding missing uniform block")
+	}
+	if err = prog.UniformBlockBinding("Params", base); err == nil {
+		t.Error("expected error binding non null terminated block name")
+	}
+	ubo, err := glgl.NewUniformBuffer([]float32{1, 1, 1, 1, 0, 0, 0, 0}, glgl.UniformBufferConfig{Base: base})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ubo.Delete()
+	if ubo.Len() != 8*4 || ubo.Binding() != base {
+		t.Fatalf("bad UBO size or binding: %d, %d", ubo.Len(), ubo.Binding())
+	}
+	ssbo, err := glgl.NewShaderStorageBuffer[float32](nil, glgl.ShaderStorageBufferConfig{Usage: glgl.ReadOrWrite, MemSize: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ssbo.Delete()
+	run := func() []float32 {
+		t.Helper()
+		if err := prog.RunCompute(1, 1, 1); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]float32, 4)
+		if err := glgl.CopyFromShaderStorageBuffer(got, ssbo); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	if got, want := run(), []float32{1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if err = glgl.Update(ubo, []float32{2, 2, 2, 2, 1, 0, 0, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := run(), []float32{3, 4, 6, 8}; !slices.Equal(got, want) {
+		t.Errorf("after update want %v, got %v", want, got)
+	}
+	if err = glgl.Update(ubo, make([]float32, 9)); err == nil {
+		t.Error("expected error updating UBO with too much data")
+	}
+}
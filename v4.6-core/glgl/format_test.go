@@ -0,0 +1,120 @@
+//go:build !tinygo && cgo
+
+package glgl_test
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/soypat/glgl/v4.6-core/glgl"
+)
+
+func TestTextureImgConfigValidate(t *testing.T) {
+	for i, test := range []struct {
+		internal int32
+		format   uint32
+		xtype    uint32
+		wantErr  bool
+	}{
+		{internal: gl.R32F, format: gl.RED, xtype: gl.FLOAT},
+		{internal: gl.RGBA32F, format: gl.RGB, xtype: gl.FLOAT},
+		{internal: 0, format: gl.RGBA, xtype: gl.UNSIGNED_BYTE},
+		{internal: gl.R32UI, format: gl.RED_INTEGER, xtype: gl.UNSIGNED_INT},
+		{internal: gl.DEPTH_COMPONENT32F, format: gl.DEPTH_COMPONENT, xtype: gl.FLOAT},
+		{internal: gl.R11F_G11F_B10F, format: gl.RGB, xtype: gl.UNSIGNED_INT_10F_11F_11F_REV},
+		{internal: gl.RGB9_E5, format: gl.RGB, xtype: gl.UNSIGNED_INT_5_9_9_9_REV},
+		{internal: gl.R11F_G11F_B10F, format: gl.RGBA, xtype: gl.UNSIGNED_INT_10F_11F_11F_REV, wantErr: true},
+		{internal: gl.R32UI, format: gl.RGB_INTEGER, xtype: gl.UNSIGNED_INT_10F_11F_11F_REV, wantErr: true},
+		{internal: gl.R32UI, format: gl.RED, xtype: gl.UNSIGNED_INT, wantErr: true},
+		{internal: gl.R32F, format: gl.RED_INTEGER, xtype: gl.INT, wantErr: true},
+		{internal: gl.R32I, format: gl.RED_INTEGER, xtype: gl.FLOAT, wantErr: true},
+		{internal: gl.DEPTH_COMPONENT24, format: gl.RED, xtype: gl.FLOAT, wantErr: true},
+		{internal: gl.RGB8, format: gl.RGBA, xtype: gl.UNSIGNED_SHORT_5_6_5, wantErr: true},
+		{internal: gl.RGBA8, format: 0, xtype: gl.UNSIGNED_BYTE, wantErr: true},
+	} {
+		cfg := glgl.TextureImgConfig{
+			InternalFormat: test.internal,
+			Format:         test.format,
+			Xtype:          test.xtype,
+		}
+		err := cfg.Validate()
+		if test.wantErr && err == nil {
+			t.Errorf("%d: expected error for internal=%#x format=%#x xtype=%#x", i, test.internal, test.format, test.xtype)
+		} else if !test.wantErr && err != nil {
+			t.Errorf("%d: unexpected error: %s", i, err)
+		}
+	}
+}
+
+func TestTextureImgConfigPixelSizeDepth(t *testing.T) {
+	for _, test := range []struct {
+		internal int32
+		xtype    uint32
+		want     int
+	}{
+		{internal: gl.DEPTH_COMPONENT32F, xtype: gl.FLOAT, want: 4},
+		{internal: gl.DEPTH_COMPONENT24, xtype: gl.UNSIGNED_INT, want: 4},
+		{internal: gl.DEPTH_COMPONENT16, xtype: gl.UNSIGNED_SHORT, want: 2},
+	} {
+		cfg := glgl.TextureImgConfig{
+			InternalFormat: test.internal,
+			Format:         gl.DEPTH_COMPONENT,
+			Xtype:          test.xtype,
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("internal=%#x: %s", test.internal, err)
+		}
+		got := cfg.PixelSize()
+		if got != test.want {
+			t.Errorf("internal=%#x: want pixel size %d, got %d", test.internal, test.want, got)
+		}
+	}
+}
+
+func TestTextureImgConfigPixelSize(t *testing.T) {
+	for _, test := range []struct {
+		format uint32
+		xtype  uint32
+		want   int
+	}{
+		{format: gl.RGBA, xtype: gl.UNSIGNED_BYTE, want: 4},
+		{format: gl.BGRA, xtype: gl.UNSIGNED_BYTE, want: 4},
+		{format: gl.RGB, xtype: gl.UNSIGNED_BYTE, want: 3},
+		{format: gl.RG, xtype: gl.HALF_FLOAT, want: 4},
+		{format: gl.RGBA, xtype: gl.FLOAT, want: 16},
+		{format: gl.RGBA, xtype: gl.UNSIGNED_INT_8_8_8_8, want: 4},
+		{format: gl.RGB, xtype: gl.UNSIGNED_SHORT_5_6_5, want: 2},
+		{format: gl.RGB, xtype: gl.UNSIGNED_INT_10F_11F_11F_REV, want: 4},
+		{format: gl.RGB, xtype: gl.UNSIGNED_INT_5_9_9_9_REV, want: 4},
+	} {
+		cfg := glgl.TextureImgConfig{Format: test.format, Xtype: test.xtype}
+		got := cfg.PixelSize()
+		if got != test.want {
+			t.Errorf("format=%#x xtype=%#x: want pixel size %d, got %d", test.format, test.xtype, test.want, got)
+		}
+	}
+}
+
+func TestTypeSizeString(t *testing.T) {
+	for _, test := range []struct {
+		typ      glgl.Type
+		wantSize int
+		wantStr  string
+	}{
+		{typ: glgl.Int8, wantSize: 1, wantStr: "Int8"},
+		{typ: glgl.Uint8, wantSize: 1, wantStr: "Uint8"},
+		{typ: glgl.Int16, wantSize: 2, wantStr: "Int16"},
+		{typ: glgl.Uint16, wantSize: 2, wantStr: "Uint16"},
+		{typ: glgl.Int32, wantSize: 4, wantStr: "Int32"},
+		{typ: glgl.Uint32, wantSize: 4, wantStr: "Uint32"},
+		{typ: glgl.Float32, wantSize: 4, wantStr: "Float32"},
+		{typ: gl.DOUBLE, wantSize: 0, wantStr: "Type(5130)"},
+	} {
+		if got := test.typ.Size(); got != test.wantSize {
+			t.Errorf("%s: want size %d, got %d", test.wantStr, test.wantSize, got)
+		}
+		if got := test.typ.String(); got != test.wantStr {
+			t.Errorf("want string %q, got %q", test.wantStr, got)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+//go:build !tinygo && cgo
+
+package glgl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// Framebuffer is a framebuffer object used for off-screen rendering to textures,
+// i.e: shadow maps, post-processing or headless image capture.
+type Framebuffer struct {
+	rid uint32
+}
+
+// NewFramebuffer creates a framebuffer object with no attachments.
+// The framebuffer is not bound to the current context.
+func NewFramebuffer() Framebuffer {
+	var fbo uint32
+	gl.CreateFramebuffers(1, &fbo)
+	return Framebuffer{rid: fbo}
+}
+
+// Bind binds the framebuffer for drawing and reading. Subsequent draw calls
+// render to its attachments.
+func (fb Framebuffer) Bind() { gl.BindFramebuffer(gl.FRAMEBUFFER, fb.rid) }
+
+// Unbind binds the default framebuffer of the window for drawing and reading.
+func (fb Framebuffer) Unbind() { gl.BindFramebuffer(gl.FRAMEBUFFER, 0) }
+
+// ID returns the framebuffer object name.
+func (fb Framebuffer) ID() uint32 { return fb.rid }
+
+// Delete deletes the framebuffer and sets its id to zero. Calling Delete on
+// a deleted framebuffer is a no-op. Attached textures are not deleted.
+func (fb *Framebuffer) Delete() {
+	if fb.rid == 0 {
+		return
+	}
+	gl.DeleteFramebuffers(1, &fb.rid)
+	fb.rid = 0
+}
+
+// AttachTexture attaches a level of tex to the framebuffer's attachment point,
+// i.e: gl.COLOR_ATTACHMENT0, gl.DEPTH_ATTACHMENT or gl.DEPTH_STENCIL_ATTACHMENT.
+// Call [Framebuffer.CheckComplete] after attaching all images.
+func (fb Framebuffer) AttachTexture(attachment uint32, tex Texture, level int32) error {
+	gl.NamedFramebufferTexture(fb.rid, attachment, tex.rid, level)
+	return Err()
+}
+
+// AttachRenderbuffer attaches rb to the framebuffer's attachment point,
+// i.e: gl.DEPTH_ATTACHMENT for a gl.DEPTH_COMPONENT24 renderbuffer or
+// gl.DEPTH_STENCIL_ATTACHMENT for a gl.DEPTH24_STENCIL8 renderbuffer.
+func (fb Framebuffer) AttachRenderbuffer(attachment uint32, rb Renderbuffer) error {
+	gl.NamedFramebufferRenderbuffer(fb.rid, attachment, gl.RENDERBUFFER, rb.rid)
+	return Err()
+}
+
+// CheckComplete returns a descriptive error if the framebuffer can not be rendered to
+// with its current attachments.
+func (fb Framebuffer) CheckComplete() error {
+	status := gl.CheckNamedFramebufferStatus(fb.rid, gl.FRAMEBUFFER)
+	if status == 0 {
+		if err := Err(); err != nil {
+			return err
+		}
+		return errors.New("unable to check framebuffer status")
+	}
+	return framebufferStatusError(status)
+}
+
+// framebufferStatusError maps a glCheckFramebufferStatus result to an error.
+func framebufferStatusError(status uint32) error {
+	var reason string
+	switch status {
+	case gl.FRAMEBUFFER_COMPLETE:
+		return nil
+	case gl.FRAMEBUFFER_UNDEFINED:
+		reason = "default framebuffer does not exist"
+	case gl.FRAMEBUFFER_INCOMPLETE_ATTACHMENT:
+		reason = "an attachment is incomplete or has a zero sized image"
+	case gl.FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT:
+		reason = "no images attached"
+	case gl.FRAMEBUFFER_INCOMPLETE_DRAW_BUFFER:
+		reason = "draw buffer refers to an attachment point with no image"
+	case gl.FRAMEBUFFER_INCOMPLETE_READ_BUFFER:
+		reason = "read buffer refers to an attachment point with no image"
+	case gl.FRAMEBUFFER_UNSUPPORTED:
+		reason = "combination of attachment internal formats not supported by the implementation"
+	case gl.FRAMEBUFFER_INCOMPLETE_MULTISAMPLE:
+		reason = "attachments have differing number of samples"
+	case gl.FRAMEBUFFER_INCOMPLETE_LAYER_TARGETS:
+		reason = "attachments are not all layered or of the same target"
+	default:
+		reason = fmt.Sprintf("unknown status %#x", status)
+	}
+	return errors.New("framebuffer incomplete: " + reason)
+}
+
+// Renderbuffer is an image for use as a framebuffer attachment that is not sampled
+// from shaders, usually the depth and stencil buffer of a render target.
+type Renderbuffer struct {
+	rid uint32
+}
+
+// NewRenderbuffer allocates a width by height renderbuffer with the given internal format,
+// i.e: gl.DEPTH_COMPONENT24, gl.DEPTH24_STENCIL8 or a color format such as gl.RGBA8.
+func NewRenderbuffer(internalFormat uint32, width, height int) (Renderbuffer, error) {
+	if width <= 0 || height <= 0 {
+		return Renderbuffer{}, errors.New("non-positive renderbuffer size")
+	}
+	var rb Renderbuffer
+	gl.CreateRenderbuffers(1, &rb.rid)
+	gl.NamedRenderbufferStorage(rb.rid, internalFormat, int32(width), int32(height))
+	if err := Err(); err != nil {
+		rb.Delete()
+		return Renderbuffer{}, err
+	}
+	return rb, nil
+}
+
+// ID returns the renderbuffer object name.
+func (rb Renderbuffer) ID() uint32 { return rb.rid }
+
+// Delete deletes the renderbuffer and sets its id to zero. Calling Delete on
+// a deleted renderbuffer is a no-op.
+func (rb *Renderbuffer) Delete() {
+	if rb.rid == 0 {
+		return
+	}
+	gl.DeleteRenderbuffers(1, &rb.rid)
+	rb.rid = 0
+}
@@ -0,0 +1,192 @@
+//go:build !tinygo && cgo
+
+package glgl_test
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/soypat/glgl/v4.6-core/glgl"
+)
+
+func TestFramebuffer(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	fb := glgl.NewFramebuffer()
+	defer fb.Delete()
+	if err := fb.CheckComplete(); err == nil {
+		t.Error("expected error for framebuffer with no attachments")
+	}
+	const width, height = 4, 2
+	tex, err := glgl.NewTextureFromImage[uint8](glgl.TextureImgConfig{
+		Type:           glgl.Texture2D,
+		Width:          width,
+		Height:         height,
+		Access:         glgl.ReadOrWrite,
+		Format:         gl.RGBA,
+		MinFilter:      gl.NEAREST,
+		MagFilter:      gl.NEAREST,
+		Xtype:          gl.UNSIGNED_BYTE,
+		InternalFormat: gl.RGBA8,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tex.Delete()
+	err = fb.AttachTexture(gl.COLOR_ATTACHMENT0, tex, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fb.CheckComplete()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fb.Bind()
+	gl.Viewport(0, 0, width, height)
+	gl.ClearColor(1, 0, 0, 1)
+	gl.Clear(gl.COLOR_BUFFER_BIT)
+	fb.Unbind()
+	got := make([]uint8, 4*width*height)
+	err = glgl.GetImage(got, tex, glgl.TextureImgConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(got); i += 4 {
+		if got[i] != 255 || got[i+1] != 0 || got[i+2] != 0 || got[i+3] != 255 {
+			t.Fatalf("pixel %d: want cleared red, got %v", i/4, got[i:i+4])
+		}
+	}
+	fb.Delete()
+	fb.Delete() // Double delete is a no-op.
+}
+
+func TestFramebufferRenderbuffer(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	const width, height = 4, 4
+	if _, err := glgl.NewRenderbuffer(gl.DEPTH_COMPONENT24, 0, height); err == nil {
+		t.Error("expected error creating zero width renderbuffer")
+	}
+	for _, test := range []struct {
+		format, attachment uint32
+	}{
+		{format: gl.DEPTH_COMPONENT24, attachment: gl.DEPTH_ATTACHMENT},
+		{format: gl.DEPTH24_STENCIL8, attachment: gl.DEPTH_STENCIL_ATTACHMENT},
+	} {
+		color, err := glgl.NewRenderbuffer(gl.RGBA8, width, height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		depth, err := glgl.NewRenderbuffer(test.format, width, height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fb := glgl.NewFramebuffer()
+		if err = fb.AttachRenderbuffer(gl.COLOR_ATTACHMENT0, color); err != nil {
+			t.Fatal(err)
+		}
+		if err = fb.AttachRenderbuffer(test.attachment, depth); err != nil {
+			t.Fatal(err)
+		}
+		if err = fb.CheckComplete(); err != nil {
+			t.Errorf("format %#x: %v", test.format, err)
+		}
+		fb.Delete()
+		depth.Delete()
+		color.Delete()
+	}
+}
+
+func TestReadPixel(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	const width, height = 4, 2
+	newAttachedFramebuffer := func(internalFormat int32, format, xtype uint32) (glgl.Framebuffer, glgl.Texture) {
+		t.Helper()
+		tex, err := glgl.NewTextureFromImage[uint8](glgl.TextureImgConfig{
+			Type:           glgl.Texture2D,
+			Width:          width,
+			Height:         height,
+			Access:         glgl.ReadOrWrite,
+			Format:         format,
+			MinFilter:      gl.NEAREST,
+			MagFilter:      gl.NEAREST,
+			Xtype:          xtype,
+			InternalFormat: internalFormat,
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fb := glgl.NewFramebuffer()
+		err = fb.AttachTexture(gl.COLOR_ATTACHMENT0, tex, 0)
+		if err == nil {
+			err = fb.CheckComplete()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fb, tex
+	}
+
+	// Clear the whole color framebuffer red and its bottom row green.
+	fb, tex := newAttachedFramebuffer(gl.RGBA8, gl.RGBA, gl.UNSIGNED_BYTE)
+	defer tex.Delete()
+	defer fb.Delete()
+	fb.Bind()
+	defer fb.Unbind()
+	gl.Viewport(0, 0, width, height)
+	gl.ClearColor(1, 0, 0, 1)
+	gl.Clear(gl.COLOR_BUFFER_BIT)
+	gl.Enable(gl.SCISSOR_TEST)
+	gl.Scissor(0, 0, width, 1)
+	gl.ClearColor(0, 1, 0, 1)
+	gl.Clear(gl.COLOR_BUFFER_BIT)
+	gl.Disable(gl.SCISSOR_TEST)
+	// y is relative to the top of the viewport so the bottom GL row is y=height-1.
+	r, g, b, a, err := glgl.ReadPixel(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if r != 255 || g != 0 || b != 0 || a != 255 {
+		t.Errorf("top row: want red, got %v %v %v %v", r, g, b, a)
+	}
+	r, g, b, a, err = glgl.ReadPixel(1, height-1)
+	if err != nil {
+		t.Fatal(err)
+	} else if r != 0 || g != 255 || b != 0 || a != 255 {
+		t.Errorf("bottom row: want green, got %v %v %v %v", r, g, b, a)
+	}
+	for _, xy := range [][2]int{{-1, 0}, {0, -1}, {width, 0}, {0, height}} {
+		if _, _, _, _, err = glgl.ReadPixel(xy[0], xy[1]); err == nil {
+			t.Errorf("expected error reading pixel %v outside viewport", xy)
+		}
+	}
+
+	// Coordinates are relative to the viewport origin.
+	ifb, itex := newAttachedFramebuffer(gl.R32UI, gl.RED_INTEGER, gl.UNSIGNED_INT)
+	defer itex.Delete()
+	defer ifb.Delete()
+	ifb.Bind()
+	gl.Viewport(1, 0, width-1, height)
+	ids := [4]uint32{7}
+	gl.ClearBufferuiv(gl.COLOR, 0, &ids[0])
+	gl.Enable(gl.SCISSOR_TEST)
+	gl.Scissor(1, 0, 1, 1) // Bottom left pixel of the viewport.
+	ids[0] = 42
+	gl.ClearBufferuiv(gl.COLOR, 0, &ids[0])
+	gl.Disable(gl.SCISSOR_TEST)
+	id, err := glgl.ReadPixelUint32(0, height-1)
+	if err != nil {
+		t.Fatal(err)
+	} else if id != 42 {
+		t.Errorf("want id 42 at bottom left of viewport, got %d", id)
+	}
+	id, err = glgl.ReadPixelUint32(1, height-1)
+	if err != nil {
+		t.Fatal(err)
+	} else if id != 7 {
+		t.Errorf("want cleared id 7, got %d", id)
+	}
+	if _, err = glgl.ReadPixelUint32(width-1, 0); err == nil {
+		t.Error("expected error reading pixel outside offset viewport")
+	}
+}
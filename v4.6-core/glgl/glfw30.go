@@ -68,5 +68,6 @@ func InitWithCurrentWindow30(cfg WindowConfig) (*glfw.Window, func(), error) {
 		return window, nil, err
 	}
 	ClearErrors()
+	workGroupCountLimits = [3]int{} // New context may have different limits.
 	return window, glfw.Terminate, nil
 }
@@ -3,8 +3,6 @@
 package glgl
 
 import (
-	"errors"
-
 	"github.com/go-gl/gl/v4.6-core/gl"
 	"github.com/go-gl/glfw/v3.3/glfw"
 )
@@ -20,9 +18,6 @@ const (
 )
 
 func InitWithCurrentWindow33(cfg WindowConfig) (*Window, func(), error) {
-	if cfg.DebugLog != nil {
-		return nil, nil, errors.New("DebugLog not supported in GLFW version 3.3")
-	}
 	if err := glfw.Init(); err != nil {
 		return nil, nil, err
 	}
@@ -40,6 +35,9 @@ func InitWithCurrentWindow33(cfg WindowConfig) (*Window, func(), error) {
 	if cfg.HideWindow {
 		glfw.WindowHint(glfw.Visible, glfw.False)
 	}
+	if cfg.DebugLog != nil {
+		glfw.WindowHint(glfw.OpenGLDebugContext, glfw.True)
+	}
 	window, err := glfw.CreateWindow(cfg.Width, cfg.Height, cfg.Title, nil, nil)
 	if err != nil {
 		return nil, nil, err
@@ -51,6 +49,10 @@ func InitWithCurrentWindow33(cfg WindowConfig) (*Window, func(), error) {
 		return &Window{window}, nil, err
 	}
 	ClearErrors()
+	workGroupCountLimits = [3]int{} // New context may have different limits.
+	if cfg.DebugLog != nil {
+		EnableDebugOutput(cfg.DebugLog)
+	}
 	return &Window{window}, glfw.Terminate, nil
 }
 
@@ -29,8 +29,50 @@ const (
 	Float32 Type = gl.FLOAT
 )
 
+// Size returns the size of the type in bytes. It returns 0 for unknown types.
+func (t Type) Size() int {
+	switch t {
+	case Int8, Uint8:
+		return 1
+	case Int16, Uint16:
+		return 2
+	case Int32, Uint32, Float32:
+		return 4
+	}
+	return 0
+}
+
+// String returns the name of the Go constant for t, i.e. "Float32". Unknown types
+// are formatted as "Type(N)" where N is the OpenGL enum value.
+func (t Type) String() (s string) {
+	switch t {
+	case Int8:
+		s = "Int8"
+	case Uint8:
+		s = "Uint8"
+	case Int16:
+		s = "Int16"
+	case Uint16:
+		s = "Uint16"
+	case Int32:
+		s = "Int32"
+	case Uint32:
+		s = "Uint32"
+	case Float32:
+		s = "Float32"
+	default:
+		s = "Type(" + strconv.Itoa(int(t)) + ")"
+	}
+	return s
+}
+
 var (
 	ErrStringNotNullTerminated = errors.New("string not null terminated")
+	// ErrNoCurrentContext is returned by [Err] when there is no OpenGL context
+	// current on the calling thread. This is usually the result of calling GL
+	// functions from a goroutine other than the one the context was created on
+	// (see [runtime.LockOSThread]) or after the context was terminated.
+	ErrNoCurrentContext = errors.New("no current OpenGL context on calling thread")
 )
 
 // MaxComputeInvocations returns the maximum total number of invocations (threads)
@@ -69,6 +111,25 @@ func MaxComputeWorkGroupCount() (Wcx, Wcy, Wcz int) {
 	return int(wcx), int(wcy), int(wcz)
 }
 
+// workGroupCountLimits caches [MaxComputeWorkGroupCount] of the current context so
+// that compute dispatches need not query the GL. It is reset when glgl initializes a
+// new context. Users creating contexts outside of glgl should call [QueryContextInfo]
+// after making a new context current to refresh it.
+var workGroupCountLimits [3]int
+
+// maxComputeWorkGroupCountCached returns the cached work group count limits, querying
+// them only on the first call after a context is initialized.
+func maxComputeWorkGroupCountCached() [3]int {
+	if workGroupCountLimits == ([3]int{}) {
+		wcx, wcy, wcz := MaxComputeWorkGroupCount()
+		if wcx <= 0 || wcy <= 0 || wcz <= 0 {
+			return [3]int{wcx, wcy, wcz} // Do not cache failed queries, i.e. no current context.
+		}
+		workGroupCountLimits = [3]int{wcx, wcy, wcz}
+	}
+	return workGroupCountLimits
+}
+
 // MaxComputeWorkGroupSize returns the maximum size of a work group that can be
 // used in each dimension (X, Y, Z) within a compute shader. This corresponds to
 // the limits for the local work group sizes specified in the shader using the
@@ -88,6 +149,49 @@ func MaxComputeWorkGroupSize() (Wsx, Wsy, Wsz int) {
 	return int(wsx), int(wsy), int(wsz)
 }
 
+// QueryContextInfo returns information on the running OpenGL implementation
+// and its limits in a single call.
+//
+// The OpenGL context must be current when calling this function.
+func QueryContextInfo() ContextInfo {
+	info := ContextInfo{
+		Vendor:                  gl.GoStr(gl.GetString(gl.VENDOR)),
+		Renderer:                gl.GoStr(gl.GetString(gl.RENDERER)),
+		Version:                 Version(),
+		GLSLVersion:             gl.GoStr(gl.GetString(gl.SHADING_LANGUAGE_VERSION)),
+		MaxComputeInvocations:   MaxComputeInvocations(),
+		MaxTextureUnits:         MaxTextureSlots(),
+		MaxCombinedTextureUnits: MaxTextureBinded(),
+	}
+	info.MaxWorkGroupCount[0], info.MaxWorkGroupCount[1], info.MaxWorkGroupCount[2] = MaxComputeWorkGroupCount()
+	info.MaxWorkGroupSize[0], info.MaxWorkGroupSize[1], info.MaxWorkGroupSize[2] = MaxComputeWorkGroupSize()
+	if info.MaxWorkGroupCount[0] > 0 && info.MaxWorkGroupCount[1] > 0 && info.MaxWorkGroupCount[2] > 0 {
+		workGroupCountLimits = info.MaxWorkGroupCount // Refresh cached limits used by RunCompute.
+	}
+	var iv [2]int32
+	var p runtime.Pinner
+	p.Pin(&iv)
+	defer p.Unpin()
+	gl.GetIntegerv(gl.MAX_IMAGE_UNITS, &iv[0])
+	gl.GetIntegerv(gl.MAX_SHADER_STORAGE_BUFFER_BINDINGS, &iv[1])
+	info.MaxImageUnits = int(iv[0])
+	info.MaxShaderStorageBufferBindings = int(iv[1])
+	return info
+}
+
+// SuggestWorkGroup suggests a local work group size and the number of work groups
+// to dispatch for totalItems work items using the limits of the running OpenGL
+// implementation. See [ContextInfo.SuggestWorkGroup] for details on the heuristic.
+//
+// The OpenGL context must be current when calling this function.
+func SuggestWorkGroup(totalItems int) (local, groups [3]int) {
+	var info ContextInfo
+	info.MaxComputeInvocations = MaxComputeInvocations()
+	info.MaxWorkGroupCount[0], info.MaxWorkGroupCount[1], info.MaxWorkGroupCount[2] = MaxComputeWorkGroupCount()
+	info.MaxWorkGroupSize[0], info.MaxWorkGroupSize[1], info.MaxWorkGroupSize[2] = MaxComputeWorkGroupSize()
+	return info.SuggestWorkGroup(totalItems)
+}
+
 // EnableDebugOutput writes debug output to log via glDebugMessageCallback.
 // If log is nil then the default slog package logger is used.
 func EnableDebugOutput(log *slog.Logger) {
@@ -125,58 +229,153 @@ func NewShaderStorageBuffer[T any](data []T, cfg ShaderStorageBufferConfig) (ssb
 		return ssbo, errors.New("undefined SSBO size")
 	} else if data != nil && cfg.MemSize != 0 {
 		return ssbo, errors.New("SSBO MemSize used only when data is nil")
-	} else if unsafe.Sizeof(z)%uintptr(cfg.MemSize) != 0 {
+	} else if data == nil && uintptr(cfg.MemSize)%unsafe.Sizeof(z) != 0 {
 		return ssbo, errors.New("SSBO MemSize should be multiple of data type length")
 	}
-
-	var p runtime.Pinner
-	p.Pin(&ssbo.id)
-	gl.GenBuffers(1, &ssbo.id)
-	p.Unpin()
-	ssbo.sz = int(unsafe.Sizeof(z)) * len(data)
-	ssbo.usage = cfg.Usage
-	ptr := unsafe.Pointer(&data[0])
-
-	ssbo.Bind()
-	gl.BufferData(gl.SHADER_STORAGE_BUFFER, ssbo.sz, ptr, uint32(cfg.Usage))
-	gl.BindBufferBase(gl.SHADER_STORAGE_BUFFER, cfg.Base, ssbo.id)
+	var ptr unsafe.Pointer
+	sz := int(cfg.MemSize)
+	if data != nil {
+		sz = int(unsafe.Sizeof(z)) * len(data)
+		ptr = unsafe.Pointer(&data[0])
+	}
+	ssbo.access = cfg.Usage
+	ssbo.base = cfg.Base
+	ssbo.obj, err = newBufferObject(gl.SHADER_STORAGE_BUFFER, accessBufferUsage(cfg.Usage), ptr, sz)
+	if err != nil {
+		return ssbo, err
+	}
+	gl.BindBufferBase(gl.SHADER_STORAGE_BUFFER, cfg.Base, ssbo.obj.rid)
 	return ssbo, Err()
 }
 
-func (ssbo ShaderStorageBuffer) Bind() {
-	gl.BindBuffer(gl.SHADER_STORAGE_BUFFER, ssbo.id)
+// accessBufferUsage returns the buffer usage hint which best describes a buffer
+// accessed by the client as specified by access.
+func accessBufferUsage(access AccessUsage) BufferUsage {
+	switch access {
+	case ReadOnly, ReadOrWrite:
+		return DynamicRead
+	case WriteOnly:
+		return DynamicDraw
+	}
+	return DynamicCopy
 }
 
-func (ssbo ShaderStorageBuffer) Delete() {
-	var p runtime.Pinner
-	p.Pin(&ssbo.id)
-	gl.DeleteBuffers(1, &ssbo.id)
-	p.Unpin()
+func (ssbo ShaderStorageBuffer) Bind() { ssbo.obj.bind() }
+
+// Len returns the size of the SSBO in bytes.
+func (ssbo ShaderStorageBuffer) Len() int { return ssbo.obj.sz }
+
+// Usage returns the access usage the SSBO was created with.
+func (ssbo ShaderStorageBuffer) Usage() AccessUsage { return ssbo.access }
+
+// Binding returns the binding point (base) of the SSBO. See [ShaderStorageBufferConfig].
+func (ssbo ShaderStorageBuffer) Binding() uint32 { return ssbo.base }
+
+// Delete deletes the SSBO and sets its id to zero. Calling Delete on
+// a deleted SSBO is a no-op. Copies of ssbo keep the deleted id and must not be used
+// or deleted after Delete since GL may reuse the id for a new object.
+func (ssbo *ShaderStorageBuffer) Delete() { ssbo.obj.delete() }
+
+// NewUniformBuffer creates a new UBO, initializes it with data and binds it to
+// the binding point given by cfg.Base. Data must follow the std140 layout of the uniform block.
+func NewUniformBuffer[T any](data []T, cfg UniformBufferConfig) (ubo UniformBuffer, err error) {
+	var z T
+	if data == nil && cfg.MemSize == 0 {
+		return ubo, errors.New("undefined UBO size")
+	} else if data != nil && cfg.MemSize != 0 {
+		return ubo, errors.New("UBO MemSize used only when data is nil")
+	} else if data != nil && len(data) == 0 {
+		return ubo, errors.New("zero length UBO data")
+	}
+	var ptr unsafe.Pointer
+	sz := int(cfg.MemSize)
+	if data != nil {
+		sz = int(unsafe.Sizeof(z)) * len(data)
+		ptr = unsafe.Pointer(&data[0])
+	}
+	ubo.base = cfg.Base
+	ubo.obj, err = newBufferObject(gl.UNIFORM_BUFFER, DynamicDraw, ptr, sz)
+	if err != nil {
+		return ubo, err
+	}
+	gl.BindBufferBase(gl.UNIFORM_BUFFER, cfg.Base, ubo.obj.rid)
+	return ubo, Err()
+}
+
+// Bind binds the UBO to its binding point. See [UniformBufferConfig].
+func (ubo UniformBuffer) Bind() { gl.BindBufferBase(gl.UNIFORM_BUFFER, ubo.base, ubo.obj.rid) }
+
+// Len returns the size of the UBO in bytes.
+func (ubo UniformBuffer) Len() int { return ubo.obj.sz }
+
+// Binding returns the binding point (base) of the UBO. See [UniformBufferConfig].
+func (ubo UniformBuffer) Binding() uint32 { return ubo.base }
+
+// Delete deletes the UBO and sets its id to zero. Calling Delete on
+// a deleted UBO is a no-op. Copies of ubo keep the deleted id and must not be used
+// or deleted after Delete since GL may reuse the id for a new object.
+func (ubo *UniformBuffer) Delete() { ubo.obj.delete() }
+
+// Update writes data to the start of the UBO via glBufferSubData.
+// It returns an error if data is larger than the UBO.
+func Update[T any](ubo UniformBuffer, data []T) error {
+	if len(data) == 0 {
+		return errors.New("zero length or nil buffer")
+	}
+	return ubo.obj.subData(0, elemSize[T]()*len(data), unsafe.Pointer(&data[0]))
 }
 
 // CopyFromShaderStorageBuffer copies data from a readable SSBO on the GPU to the destination buffer.
 func CopyFromShaderStorageBuffer[T any](dst []T, ssbo ShaderStorageBuffer) error {
 	dstSize := elemSize[T]() * len(dst)
-	if ssbo.usage != ReadOnly && ssbo.usage != ReadOrWrite {
+	if ssbo.access != ReadOnly && ssbo.access != ReadOrWrite {
 		return errors.New("attempted to read from non-readable SSBO")
-	} else if ssbo.sz < dstSize {
+	} else if ssbo.obj.sz < dstSize {
 		return errors.New("attempted to read more bytes than allocated for SSBO")
 	} else if len(dst) == 0 {
 		return errors.New("zero length or nil buffer")
 	}
-	ssbo.Bind()
-	ptr := gl.MapBufferRange(gl.SHADER_STORAGE_BUFFER, 0, dstSize, gl.MAP_READ_BIT)
-	if ptr == nil {
-		err := Err()
-		if err != nil {
-			return err
-		}
-		return errors.New("failed to map buffer")
+	ptr, err := ssbo.obj.mapRange(0, dstSize, ReadOnly)
+	if err != nil {
+		return err
 	}
-	defer gl.UnmapBuffer(gl.SHADER_STORAGE_BUFFER)
 	gpuBytes := unsafe.Slice((*byte)(ptr), dstSize)
 	bufBytes := unsafe.Slice((*byte)(unsafe.Pointer(&dst[0])), dstSize)
 	copy(bufBytes, gpuBytes)
+	return ssbo.obj.unmap()
+}
+
+// ReadShaderStorageBuffer allocates a slice with enough elements to hold the
+// entire contents of a readable SSBO and copies the SSBO's data into it.
+// It returns an error if the SSBO size is not a multiple of T's size.
+func ReadShaderStorageBuffer[T any](ssbo ShaderStorageBuffer) ([]T, error) {
+	sz := elemSize[T]()
+	if sz == 0 {
+		return nil, errors.New("zero sized SSBO element type")
+	} else if ssbo.obj.sz%sz != 0 {
+		return nil, errors.New("SSBO size not a multiple of element size")
+	}
+	dst := make([]T, ssbo.obj.sz/sz)
+	err := CopyFromShaderStorageBuffer(dst, ssbo)
+	if err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// CopyBuffer copies size bytes from src starting at srcOffset to dst starting at dstOffset
+// via glCopyNamedBufferSubData. The data never leaves the GPU.
+func CopyBuffer(dst, src ShaderStorageBuffer, dstOffset, srcOffset, size int) error {
+	if dstOffset < 0 || srcOffset < 0 || size <= 0 {
+		return errors.New("negative offset or non-positive size")
+	} else if srcOffset+size > src.obj.sz {
+		return errors.New("attempted to copy more bytes than allocated for source SSBO")
+	} else if dstOffset+size > dst.obj.sz {
+		return errors.New("attempted to copy more bytes than allocated for destination SSBO")
+	} else if dst.obj.rid == src.obj.rid && dstOffset < srcOffset+size && srcOffset < dstOffset+size {
+		return errors.New("overlapping copy ranges within same SSBO")
+	}
+	gl.CopyNamedBufferSubData(src.obj.rid, dst.obj.rid, srcOffset, dstOffset, size)
 	return Err()
 }
 
@@ -186,12 +385,71 @@ func NewVAO() VertexArray {
 	var vao uint32
 	gl.GenVertexArrays(1, &vao)
 	gl.BindVertexArray(vao)
-	return VertexArray{rid: vao}
+	return VertexArray{rid: vao, attribs: new([]vertexAttrib)}
 }
 
 func (vao VertexArray) Bind()   { gl.BindVertexArray(vao.rid) }
 func (vao VertexArray) Unbind() { gl.BindVertexArray(0) }
 
+// Layouts returns the attribute layouts added to the vertex array in the order
+// they were added. The Stride of tightly packed layouts is set to the computed stride.
+func (vao VertexArray) Layouts() []AttribLayout {
+	if vao.attribs == nil {
+		return nil
+	}
+	layouts := make([]AttribLayout, len(*vao.attribs))
+	for i, attr := range *vao.attribs {
+		layouts[i] = attr.layout
+	}
+	return layouts
+}
+
+// Rebind binds the vertex array and re-issues the attribute pointer calls of all
+// attributes added to it using the attribute locations resolved when they were added.
+// Rebind is useful to rule out lost vertex array state when nothing is drawn.
+func (vao VertexArray) Rebind() error {
+	vao.Bind()
+	if vao.attribs == nil {
+		return Err()
+	}
+	for _, attr := range *vao.attribs {
+		attr.vbo.bind()
+		attr.pointer()
+	}
+	return Err()
+}
+
+// record stores attr in the vertex array, replacing any attribute at the same location.
+func (vao VertexArray) record(attr vertexAttrib) {
+	if vao.attribs == nil {
+		return // Zero value VertexArray.
+	}
+	for i := range *vao.attribs {
+		if (*vao.attribs)[i].loc == attr.loc {
+			(*vao.attribs)[i] = attr
+			return
+		}
+	}
+	*vao.attribs = append(*vao.attribs, attr)
+}
+
+// pointer enables the attribute and sets its data layout on the currently bound
+// vertex array to read from the currently bound GL_ARRAY_BUFFER.
+func (attr vertexAttrib) pointer() {
+	layout := attr.layout
+	gl.EnableVertexAttribArray(attr.loc)
+	if attr.integer {
+		gl.VertexAttribIPointerWithOffset(attr.loc, int32(layout.Packing), uint32(layout.Type),
+			int32(layout.Stride), uintptr(layout.Offset))
+	} else {
+		gl.VertexAttribPointerWithOffset(attr.loc, int32(layout.Packing), uint32(layout.Type),
+			layout.Normalize, int32(layout.Stride), uintptr(layout.Offset))
+	}
+}
+
+// AddAttribute adds an attribute to the currently bound vertex array which
+// reads from vbo as described by layout. See [VertexArray.AddAttributeFromBuffer]
+// which binds the vertex array before adding the attribute.
 func (vao VertexArray) AddAttribute(vbo VertexBuffer, layout AttribLayout) error {
 	if !strings.HasSuffix(layout.Name, "\x00") {
 		return ErrStringNotNullTerminated
@@ -199,22 +457,127 @@ func (vao VertexArray) AddAttribute(vbo VertexBuffer, layout AttribLayout) error
 	if layout.Type == 0 || layout.Packing < 1 || layout.Packing > 4 {
 		return errors.New("invalid argument")
 	}
+	stride := layout.Stride
+	if stride == 0 {
+		// Tightly packed attributes.
+		stride = layout.Packing * layout.Type.Size()
+		if stride == 0 {
+			return errors.New("unknown attribute type size, Stride must be set")
+		}
+	}
 	vbo.Bind()
 	vertAttrib := gl.GetAttribLocation(layout.Program.rid, gl.Str(layout.Name))
 	if vertAttrib < 0 {
 		return errors.New("vertex attribute not found:" + layout.Name[:len(layout.Name)-1])
 	}
-	gl.EnableVertexAttribArray(uint32(vertAttrib))
+	layout.Stride = stride
+	attr := vertexAttrib{layout: layout, vbo: vbo.obj, loc: uint32(vertAttrib)}
 	// VAO: Vertex Array Object is bound to the vertex buffer on this call.
 	// What this line is saying is that `vertAttrib`` index is going to be bound
 	// to the current gl.ARRAY_BUFFER (vbo).
 	// It also stores size, type, normalized, stride and pointer as vertex array
 	// state, in addition to the current vertex array buffer object binding. https://registry.khronos.org/OpenGL-Refpages/gl4/html/glVertexAttribPointer.xhtml
-	gl.VertexAttribPointerWithOffset(uint32(vertAttrib), int32(layout.Packing), uint32(layout.Type),
-		layout.Normalize, int32(layout.Stride), uintptr(layout.Offset))
+	attr.pointer()
+	if err := Err(); err != nil {
+		return err
+	}
+	vao.record(attr)
+	return nil
+}
+
+// AddAttributeFromBuffer binds the vertex array and adds an attribute which reads
+// from vbo as described by layout. Each attribute of a vertex array can be read from
+// a different buffer, which allows storing each attribute in its own buffer
+// (structure of arrays) instead of interleaving them in a single buffer:
+//
+//	vao.AddAttributeFromBuffer(positions, glgl.AttribLayout{Name: "position\x00", ...})
+//	vao.AddAttributeFromBuffer(colors, glgl.AttribLayout{Name: "color\x00", ...})
+//
+// The buffer an attribute reads from is stored in the vertex array at the time of
+// the call, so rebinding GL_ARRAY_BUFFER afterwards does not affect the attribute.
+func (vao VertexArray) AddAttributeFromBuffer(vbo VertexBuffer, layout AttribLayout) error {
+	vao.Bind()
+	return vao.AddAttribute(vbo, layout)
+}
+
+// BindProgramAttributes configures the vertex array to read all of prog's active
+// attributes from vbo. The data in vbo must be interleaved and tightly packed with
+// attributes in order of location, which usually matches declaration order in the
+// shader source. Integer GLSL attributes (int, ivec*, uint, uvec*) are read as
+// integers and the rest as floats. Matrix and double attributes are not supported,
+// use [VertexArray.AddAttribute] for these or for data that is not tightly packed.
+func (vao VertexArray) BindProgramAttributes(vbo VertexBuffer, prog Program) error {
+	attrs, err := prog.ActiveAttributes()
+	if err != nil {
+		return err
+	}
+	stride := 0
+	for _, attr := range attrs {
+		typ, packing, ok := attribTypePacking(attr.GLSLType)
+		if !ok {
+			return fmt.Errorf("unsupported type %#x of vertex attribute %q", attr.GLSLType, attr.Name)
+		}
+		stride += packing * typ.Size() * attr.Size
+	}
+	vao.Bind()
+	vbo.Bind()
+	offset := 0
+	for _, attr := range attrs {
+		typ, packing, _ := attribTypePacking(attr.GLSLType)
+		for i := 0; i < attr.Size; i++ {
+			// Array attributes take up consecutive locations.
+			va := vertexAttrib{
+				layout: AttribLayout{
+					Program: prog,
+					Type:    typ,
+					Name:    attr.Name + "\x00",
+					Packing: packing,
+					Stride:  stride,
+					Offset:  offset,
+				},
+				vbo:     vbo.obj,
+				loc:     attr.Location + uint32(i),
+				integer: typ != Float32,
+			}
+			va.pointer()
+			vao.record(va)
+			offset += packing * typ.Size()
+		}
+	}
 	return Err()
 }
 
+// attribTypePacking returns the component type and number of components of a GLSL attribute type.
+func attribTypePacking(glslType uint32) (typ Type, packing int, ok bool) {
+	switch glslType {
+	case gl.FLOAT:
+		return Float32, 1, true
+	case gl.FLOAT_VEC2:
+		return Float32, 2, true
+	case gl.FLOAT_VEC3:
+		return Float32, 3, true
+	case gl.FLOAT_VEC4:
+		return Float32, 4, true
+	case gl.INT:
+		return Int32, 1, true
+	case gl.INT_VEC2:
+		return Int32, 2, true
+	case gl.INT_VEC3:
+		return Int32, 3, true
+	case gl.INT_VEC4:
+		return Int32, 4, true
+	case gl.UNSIGNED_INT:
+		return Uint32, 1, true
+	case gl.UNSIGNED_INT_VEC2:
+		return Uint32, 2, true
+	case gl.UNSIGNED_INT_VEC3:
+		return Uint32, 3, true
+	case gl.UNSIGNED_INT_VEC4:
+		return Uint32, 4, true
+	}
+	return 0, 0, false
+}
+
 // Buffer Usages. See BufferUsage documentation for detailed information.
 const (
 	StaticDraw  BufferUsage = gl.STATIC_DRAW
@@ -228,86 +591,234 @@ const (
 	StreamCopy  BufferUsage = gl.STREAM_COPY
 )
 
-// NewVertexBuffer creates a new vertex buffer and binds it.
-func NewVertexBuffer[T any](usage BufferUsage, data []T) (VertexBuffer, error) {
-	var vbo VertexBuffer
-	vertexSize := unsafe.Sizeof(data[0])
-	vertPtr := unsafe.Pointer(&data[0])
-	gl.GenBuffers(1, &vbo.rid)
-	gl.BindBuffer(gl.ARRAY_BUFFER, vbo.rid)
-	gl.BufferData(gl.ARRAY_BUFFER, int(vertexSize)*len(data), vertPtr, uint32(usage))
-	return vbo, Err()
+// NewBuffer creates a buffer object bound to target, i.e: gl.ARRAY_BUFFER, and initializes it with data.
+// The number of elements of the buffer is fixed to len(data) on creation.
+func NewBuffer[T any](target uint32, usage BufferUsage, data []T) (Buffer[T], error) {
+	sz := elemSize[T]()
+	if len(data) == 0 {
+		return Buffer[T]{}, errors.New("zero length or nil buffer")
+	} else if sz == 0 {
+		return Buffer[T]{}, errors.New("zero sized buffer element type")
+	}
+	obj, err := newBufferObject(target, usage, unsafe.Pointer(&data[0]), sz*len(data))
+	return Buffer[T]{obj: obj}, err
 }
 
-func (vbo VertexBuffer) Bind() {
-	gl.BindBuffer(gl.ARRAY_BUFFER, vbo.rid)
+func (b Buffer[T]) Bind()   { b.obj.bind() }
+func (b Buffer[T]) Unbind() { b.obj.unbind() }
+
+// ID returns the OpenGL identifier of the buffer.
+func (b Buffer[T]) ID() uint32 { return b.obj.rid }
+
+// Target returns the target the buffer is bound to.
+func (b Buffer[T]) Target() uint32 { return b.obj.target }
+
+// Usage returns the usage hint the buffer was created with.
+func (b Buffer[T]) Usage() BufferUsage { return b.obj.usage }
+
+// Len returns the number of elements of type T the buffer holds.
+func (b Buffer[T]) Len() int { return b.obj.sz / elemSize[T]() }
+
+// Update writes data to the buffer starting at element offset.
+func (b Buffer[T]) Update(offset int, data []T) error {
+	if len(data) == 0 {
+		return errors.New("zero length or nil buffer")
+	}
+	sz := elemSize[T]()
+	return b.obj.subData(offset*sz, len(data)*sz, unsafe.Pointer(&data[0]))
 }
-func (vbo VertexBuffer) Unbind() {
-	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+
+// Get reads the buffer's data starting at element offset into dst.
+func (b Buffer[T]) Get(dst []T, offset int) error {
+	if len(dst) == 0 {
+		return errors.New("zero length or nil buffer")
+	}
+	sz := elemSize[T]()
+	return b.obj.getSubData(offset*sz, len(dst)*sz, unsafe.Pointer(&dst[0]))
 }
-func (vbo VertexBuffer) Delete() {
-	gl.DeleteBuffers(1, &vbo.rid)
+
+// Map maps length elements of the buffer starting at element offset to client
+// memory. The returned slice must not be used after calling [Buffer.Unmap].
+func (b Buffer[T]) Map(offset, length int, access AccessUsage) ([]T, error) {
+	if length <= 0 {
+		return nil, errors.New("non-positive length to map")
+	}
+	sz := elemSize[T]()
+	ptr, err := b.obj.mapRange(offset*sz, length*sz, access)
+	if err != nil {
+		return nil, err
+	}
+	return unsafe.Slice((*T)(ptr), length), nil
 }
 
-const WriteOnly, ReadOnly, ReadOrWrite AccessUsage = gl.WRITE_ONLY, gl.READ_ONLY, gl.READ_WRITE
+// Unmap unmaps the buffer's memory mapped with [Buffer.Map].
+func (b Buffer[T]) Unmap() error { return b.obj.unmap() }
 
-// MapBufferData maps vertex buffer memory on the GPU to client space in the form
-// of a slice.
-func MapBufferData[T any](vbo VertexBuffer, length int, access AccessUsage) ([]T, error) {
-	vertexSize := unsafe.Sizeof(*new(T))
-	ptr := gl.MapNamedBufferRange(vbo.rid, 0, int(vertexSize)*length, uint32(access))
+// Delete deletes the buffer and sets its id to zero. Calling Delete on
+// a deleted buffer is a no-op. Copies of b keep the deleted id and must not be used
+// or deleted after Delete since GL may reuse the id for a new object.
+func (b *Buffer[T]) Delete() { b.obj.delete() }
+
+func newBufferObject(target uint32, usage BufferUsage, data unsafe.Pointer, size int) (bufferObject, error) {
+	obj := bufferObject{target: target, usage: usage, sz: size}
+	var p runtime.Pinner
+	p.Pin(&obj.rid)
+	gl.GenBuffers(1, &obj.rid)
+	p.Unpin()
+	gl.BindBuffer(target, obj.rid)
+	gl.BufferData(target, size, data, uint32(usage))
+	return obj, Err()
+}
+
+func (obj bufferObject) bind()   { gl.BindBuffer(obj.target, obj.rid) }
+func (obj bufferObject) unbind() { gl.BindBuffer(obj.target, 0) }
+
+func (obj *bufferObject) delete() {
+	if obj.rid == 0 {
+		return // Already deleted or never created.
+	}
+	var p runtime.Pinner
+	p.Pin(&obj.rid)
+	gl.DeleteBuffers(1, &obj.rid)
+	p.Unpin()
+	obj.rid = 0
+}
+
+// checkRange checks the byte range starting at offset of length size lies within the buffer.
+func (obj bufferObject) checkRange(offset, size int) error {
+	if offset < 0 || size < 0 {
+		return errors.New("negative buffer offset or size")
+	} else if offset+size > obj.sz {
+		return errors.New("buffer range exceeds bytes allocated for buffer")
+	}
+	return nil
+}
+
+func (obj bufferObject) subData(offset, size int, data unsafe.Pointer) error {
+	if err := obj.checkRange(offset, size); err != nil {
+		return err
+	}
+	obj.bind()
+	gl.BufferSubData(obj.target, offset, size, data)
+	return Err()
+}
+
+func (obj bufferObject) getSubData(offset, size int, dst unsafe.Pointer) error {
+	if err := obj.checkRange(offset, size); err != nil {
+		return err
+	}
+	obj.bind()
+	gl.GetBufferSubData(obj.target, offset, size, dst)
+	return Err()
+}
+
+func (obj bufferObject) mapRange(offset, size int, access AccessUsage) (unsafe.Pointer, error) {
+	if err := obj.checkRange(offset, size); err != nil {
+		return nil, err
+	}
+	var bits uint32
+	switch access {
+	case ReadOnly:
+		bits = gl.MAP_READ_BIT
+	case WriteOnly:
+		bits = gl.MAP_WRITE_BIT
+	case ReadOrWrite:
+		bits = gl.MAP_READ_BIT | gl.MAP_WRITE_BIT
+	default:
+		return nil, errors.New("invalid buffer map access")
+	}
+	ptr := gl.MapNamedBufferRange(obj.rid, offset, size, bits)
 	err := Err()
 	if err != nil {
 		return nil, err
 	}
 	if ptr == nil {
-		panic("got nil pointer from MapNamedBufferRange")
+		return nil, errors.New("got nil pointer from MapNamedBufferRange")
 	}
-
-	return unsafe.Slice((*T)(ptr), length), nil
+	return ptr, nil
 }
 
-func GetBufferData[T any](dst []T, vbo VertexBuffer) error {
-	vertexSize := unsafe.Sizeof(dst[0])
-	vertPtr := unsafe.Pointer(&dst[0])
-	// gl.GetBufferDat
-	gl.GetBufferSubData(gl.ARRAY_BUFFER, 0, len(dst)*int(vertexSize), vertPtr)
-	// gl.GetNamedBufferSubData(vbo.rid, 0, len(dst)*int(vertexSize), vertPtr)
+func (obj bufferObject) unmap() error {
+	gl.UnmapNamedBuffer(obj.rid)
 	return Err()
 }
 
-func NewIndexBuffer(data []uint32) (IndexBuffer, error) {
-	return newIndexBuffer(gl.STATIC_DRAW, data)
+// NewVertexBuffer creates a new vertex buffer and binds it.
+func NewVertexBuffer[T any](usage BufferUsage, data []T) (VertexBuffer, error) {
+	if len(data) == 0 {
+		return VertexBuffer{}, errors.New("empty vertex data")
+	}
+	obj, err := newBufferObject(gl.ARRAY_BUFFER, usage, unsafe.Pointer(&data[0]), elemSize[T]()*len(data))
+	return VertexBuffer{obj: obj}, err
 }
 
-func newIndexBuffer(usage uint32, data []uint32) (IndexBuffer, error) {
-	var ibo IndexBuffer
-	const IndexSize = unsafe.Sizeof(data[0])
-	vertPtr := unsafe.Pointer(&data[0])
-	gl.GenBuffers(1, &ibo.rid)
-	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, ibo.rid)
-	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, int(IndexSize)*len(data), vertPtr, usage)
-	return ibo, Err()
+func (vbo VertexBuffer) Bind()   { vbo.obj.bind() }
+func (vbo VertexBuffer) Unbind() { vbo.obj.unbind() }
+
+// Delete deletes the vertex buffer and sets its id to zero. Calling Delete on
+// a deleted vertex buffer is a no-op. Copies of vbo keep the deleted id and must not be used
+// or deleted after Delete since GL may reuse the id for a new object.
+func (vbo *VertexBuffer) Delete() { vbo.obj.delete() }
+
+// ID returns the OpenGL identifier of the vertex buffer.
+func (vbo VertexBuffer) ID() uint32 {
+	return vbo.obj.rid
 }
 
-func (vbo IndexBuffer) Bind() {
-	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, vbo.rid)
+const WriteOnly, ReadOnly, ReadOrWrite AccessUsage = gl.WRITE_ONLY, gl.READ_ONLY, gl.READ_WRITE
+
+// MapBufferData maps vertex buffer memory on the GPU to client space in the form
+// of a slice of length elements of type T. It returns an error if the mapped
+// range would exceed the size of the buffer allocated on creation.
+func MapBufferData[T any](vbo VertexBuffer, length int, access AccessUsage) ([]T, error) {
+	if length <= 0 {
+		return nil, errors.New("non-positive length to map")
+	}
+	ptr, err := vbo.obj.mapRange(0, elemSize[T]()*length, access)
+	if err != nil {
+		return nil, err
+	}
+	return unsafe.Slice((*T)(ptr), length), nil
 }
 
-func (vbo IndexBuffer) Unbind() {
-	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, 0)
+// GetBufferData reads the vertex buffer's data from the GPU into dst.
+// It returns an error if dst is larger than the buffer allocated on creation.
+func GetBufferData[T any](dst []T, vbo VertexBuffer) error {
+	if len(dst) == 0 {
+		return errors.New("zero length or nil buffer")
+	}
+	return vbo.obj.getSubData(0, elemSize[T]()*len(dst), unsafe.Pointer(&dst[0]))
 }
 
-func (vbo IndexBuffer) Delete() {
-	gl.DeleteBuffers(1, &vbo.rid)
+func NewIndexBuffer(data []uint32) (IndexBuffer, error) {
+	return newIndexBuffer(StaticDraw, data)
 }
 
+func newIndexBuffer(usage BufferUsage, data []uint32) (IndexBuffer, error) {
+	if len(data) == 0 {
+		return IndexBuffer{}, errors.New("empty index data")
+	}
+	obj, err := newBufferObject(gl.ELEMENT_ARRAY_BUFFER, usage, unsafe.Pointer(&data[0]), 4*len(data))
+	return IndexBuffer{obj: obj}, err
+}
+
+func (ibo IndexBuffer) Bind()   { ibo.obj.bind() }
+func (ibo IndexBuffer) Unbind() { ibo.obj.unbind() }
+
+// Delete deletes the index buffer and sets its id to zero. Calling Delete on
+// a deleted index buffer is a no-op. Copies of ibo keep the deleted id and must not be used
+// or deleted after Delete since GL may reuse the id for a new object.
+func (ibo *IndexBuffer) Delete() { ibo.obj.delete() }
+
 type Texture struct {
 	rid uint32
 	// Usually GL_TEXTURE_2D.
 	target uint32
 	// Usually TEXTURE0.
 	unit uint32
+	// cfg is the configuration the texture storage was last allocated with.
+	// It is shared by all copies of the texture so that they see a [Texture.Resize].
+	cfg *TextureImgConfig
 }
 
 func MaxTextureSlots() (textureUnits int) {
@@ -336,6 +847,81 @@ func (t Texture) Bind(activeSlot int) {
 	gl.BindTexture(t.target, t.rid)
 }
 
+// BindImage binds a level of the texture to an image unit via glBindImageTexture
+// so that it may be accessed as an image2D from shaders. The image unit is the
+// `binding` layout parameter in the shader source code.
+// Format must be compatible with the texture's internal format, i.e: gl.R32F, gl.RGBA32F.
+func (t Texture) BindImage(imageUnit uint32, level int32, layered bool, layer int32, access AccessUsage, format uint32) error {
+	gl.BindImageTexture(imageUnit, t.rid, level, layered, layer, uint32(access), format)
+	return Err()
+}
+
+// Config returns the configuration the texture's storage was last allocated with,
+// i.e: by [NewTextureFromImage] or [Texture.Resize].
+func (t Texture) Config() TextureImgConfig {
+	if t.cfg == nil {
+		return TextureImgConfig{}
+	}
+	return *t.cfg
+}
+
+// imageConfig returns the configuration used to transfer the texture's image. Zero
+// valued size, level and format fields of cfg are taken from the texture's stored
+// configuration. An error is returned if a non-zero field does not match the stored value.
+func (t Texture) imageConfig(cfg TextureImgConfig) (TextureImgConfig, error) {
+	stored := t.Config()
+	if err := matchImageField(&cfg.Width, stored.Width, "width"); err != nil {
+		return cfg, err
+	} else if err = matchImageField(&cfg.Height, stored.Height, "height"); err != nil {
+		return cfg, err
+	} else if err = matchImageField(&cfg.Level, stored.Level, "level"); err != nil {
+		return cfg, err
+	} else if err = matchImageField(&cfg.Format, stored.Format, "format"); err != nil {
+		return cfg, err
+	} else if err = matchImageField(&cfg.Xtype, stored.Xtype, "xtype"); err != nil {
+		return cfg, err
+	} else if err = matchImageField(&cfg.InternalFormat, stored.InternalFormat, "internal format"); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func matchImageField[T comparable](got *T, stored T, name string) error {
+	var z T
+	if *got == z {
+		*got = stored
+	} else if stored != z && *got != stored {
+		return errors.New("texture " + name + " does not match texture config")
+	}
+	return nil
+}
+
+// Resize reallocates the texture's storage with the new width and height keeping the
+// format, type and level the texture was created with. The texture's previous contents
+// are lost and the new contents are undefined. The texture name is preserved so
+// framebuffer attachments and image unit bindings of the texture remain valid.
+// Only 2D textures may be resized.
+func (t *Texture) Resize(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return errors.New("non-positive texture size")
+	} else if t.rid == 0 || t.cfg == nil {
+		return errors.New("resize of deleted or uninitialized texture")
+	} else if t.target != gl.TEXTURE_2D {
+		return errors.New("resize only supported for 2D textures")
+	}
+	cfg := *t.cfg
+	cfg.Width, cfg.Height = width, height
+	internalFormat := zdefault(cfg.InternalFormat, int32(cfg.Format))
+	gl.BindTexture(t.target, t.rid)
+	gl.TexImage2D(t.target, cfg.Level, internalFormat, int32(width), int32(height),
+		cfg.Border, cfg.Format, cfg.Xtype, nil)
+	if err := Err(); err != nil {
+		return err
+	}
+	*t.cfg = cfg
+	return nil
+}
+
 //	func (t Texture) Unbind() {
 //		if err := Err(); err != nil {
 //			panic(err)
@@ -346,45 +932,206 @@ func (t Texture) Bind(activeSlot int) {
 //			panic(err)
 //		}
 //	}
-func (t Texture) Delete() {
-	// gl.BindTexture(t.target, 0)
-	// if err := Err(); err != nil {
-	// 	panic(err)
-	// }
+
+// Delete deletes the texture and sets its id to zero. Calling Delete on
+// a deleted texture is a no-op. Copies of t keep the deleted id and must not be used
+// or deleted after Delete since GL may reuse the id for a new object.
+func (t *Texture) Delete() {
+	if t.rid == 0 {
+		return
+	}
 	gl.DeleteTextures(1, &t.rid)
 	if err := Err(); err != nil {
 		panic(err)
 	}
+	t.rid = 0
 }
 
 const Texture2D TextureType = gl.TEXTURE_2D
 
+// PixelSize returns the size in bytes of a single pixel of the pixel data
+// described by Format and Xtype. PixelSize panics if the size is unknown.
 func (cfg TextureImgConfig) PixelSize() int {
+	sz, ok := cfg.pixelSize()
+	if !ok {
+		panic("unsupported format or xtype. file an issue or PR with its addition!")
+	}
+	return sz
+}
+
+// pixelSize returns the size in bytes of a single pixel and true if
+// the format and data type combination is known.
+func (cfg TextureImgConfig) pixelSize() (int, bool) {
+	switch cfg.Xtype {
+	case gl.UNSIGNED_BYTE_3_3_2, gl.UNSIGNED_BYTE_2_3_3_REV:
+		return 1, true // Packed types hold the whole pixel.
+	case gl.UNSIGNED_SHORT_5_6_5, gl.UNSIGNED_SHORT_5_6_5_REV, gl.UNSIGNED_SHORT_4_4_4_4,
+		gl.UNSIGNED_SHORT_4_4_4_4_REV, gl.UNSIGNED_SHORT_5_5_5_1, gl.UNSIGNED_SHORT_1_5_5_5_REV:
+		return 2, true
+	case gl.UNSIGNED_INT_8_8_8_8, gl.UNSIGNED_INT_8_8_8_8_REV, gl.UNSIGNED_INT_10_10_10_2,
+		gl.UNSIGNED_INT_2_10_10_10_REV, gl.UNSIGNED_INT_10F_11F_11F_REV, gl.UNSIGNED_INT_5_9_9_9_REV,
+		gl.UNSIGNED_INT_24_8:
+		return 4, true
+	case gl.FLOAT_32_UNSIGNED_INT_24_8_REV:
+		return 8, true
+	}
 	var mul, sz int
 	switch cfg.Format {
-	case gl.RED, gl.RED_INTEGER:
+	case gl.RED, gl.GREEN, gl.BLUE, gl.RED_INTEGER, gl.GREEN_INTEGER, gl.BLUE_INTEGER,
+		gl.DEPTH_COMPONENT, gl.STENCIL_INDEX:
 		mul = 1
 	case gl.RG, gl.RG_INTEGER:
 		mul = 2
-	case gl.RGB, gl.RGB_INTEGER:
+	case gl.RGB, gl.BGR, gl.RGB_INTEGER, gl.BGR_INTEGER:
 		mul = 3
-	case gl.RGBA, gl.RGBA_INTEGER:
+	case gl.RGBA, gl.BGRA, gl.RGBA_INTEGER, gl.BGRA_INTEGER:
 		mul = 4
 	default:
-		panic("unsupported format. file an issue or PR with its addition!")
+		return 0, false
 	}
 	switch cfg.Xtype {
-	case gl.FLOAT, gl.INT:
+	case gl.UNSIGNED_BYTE, gl.BYTE:
+		sz = 1
+	case gl.UNSIGNED_SHORT, gl.SHORT, gl.HALF_FLOAT:
+		sz = 2
+	case gl.FLOAT, gl.INT, gl.UNSIGNED_INT:
 		sz = 4
 	default:
-		panic("unsupported xtype. file an issue or PR with its addition!")
+		return 0, false
+	}
+	return mul * sz, true
+}
+
+// Validate checks that the texture's internal format, pixel data format and
+// pixel data type are compatible with one another so that mismatches are reported
+// with a descriptive error instead of an opaque GL_INVALID_OPERATION.
+// Validate performs no calls to the GL.
+func (cfg TextureImgConfig) Validate() error {
+	fmtClass, ok := pixelFormatClass(cfg.Format)
+	if !ok {
+		return fmt.Errorf("unknown or unsupported texture pixel data format %#x", cfg.Format)
+	}
+	switch cfg.Xtype {
+	case gl.UNSIGNED_BYTE, gl.BYTE, gl.UNSIGNED_SHORT, gl.SHORT, gl.UNSIGNED_INT, gl.INT:
+	case gl.HALF_FLOAT, gl.FLOAT:
+		if fmtClass == classInteger {
+			return errors.New("integer texture pixel data format cannot be used with floating point pixel data type")
+		}
+	case gl.UNSIGNED_BYTE_3_3_2, gl.UNSIGNED_BYTE_2_3_3_REV, gl.UNSIGNED_SHORT_5_6_5, gl.UNSIGNED_SHORT_5_6_5_REV:
+		if cfg.Format != gl.RGB && cfg.Format != gl.RGB_INTEGER {
+			return errors.New("packed 3 component pixel data type requires RGB pixel data format")
+		}
+	case gl.UNSIGNED_SHORT_4_4_4_4, gl.UNSIGNED_SHORT_4_4_4_4_REV, gl.UNSIGNED_SHORT_5_5_5_1, gl.UNSIGNED_SHORT_1_5_5_5_REV,
+		gl.UNSIGNED_INT_8_8_8_8, gl.UNSIGNED_INT_8_8_8_8_REV, gl.UNSIGNED_INT_10_10_10_2, gl.UNSIGNED_INT_2_10_10_10_REV:
+		switch cfg.Format {
+		case gl.RGBA, gl.BGRA, gl.RGBA_INTEGER, gl.BGRA_INTEGER:
+		default:
+			return errors.New("packed 4 component pixel data type requires RGBA or BGRA pixel data format")
+		}
+	case gl.UNSIGNED_INT_10F_11F_11F_REV, gl.UNSIGNED_INT_5_9_9_9_REV:
+		if cfg.Format != gl.RGB {
+			return errors.New("packed floating point pixel data type requires RGB pixel data format")
+		}
+	case gl.UNSIGNED_INT_24_8, gl.FLOAT_32_UNSIGNED_INT_24_8_REV:
+		if cfg.Format != gl.DEPTH_STENCIL {
+			return errors.New("packed depth-stencil pixel data type requires DEPTH_STENCIL pixel data format")
+		}
+	default:
+		return fmt.Errorf("unknown or unsupported texture pixel data type %#x", cfg.Xtype)
+	}
+	if cfg.InternalFormat == 0 {
+		return nil // Internal format will be same as Format.
+	}
+	intClass, ok := internalFormatClass(cfg.InternalFormat)
+	if !ok {
+		return nil // Compressed or uncommon internal format, let the GL decide.
+	}
+	if intClass != fmtClass {
+		return fmt.Errorf("texture internal format %#x (%s) incompatible with pixel data format %#x (%s)",
+			cfg.InternalFormat, intClass, cfg.Format, fmtClass)
+	}
+	return nil
+}
+
+// formatClass is the class of texture format which determines compatibility
+// between an internal format and a pixel data format.
+type formatClass uint8
+
+const (
+	classColor formatClass = iota // Normalized or floating point color.
+	classInteger
+	classDepth
+	classStencil
+	classDepthStencil
+)
+
+func (fc formatClass) String() string {
+	switch fc {
+	case classColor:
+		return "color"
+	case classInteger:
+		return "integer color"
+	case classDepth:
+		return "depth"
+	case classStencil:
+		return "stencil"
+	case classDepthStencil:
+		return "depth-stencil"
 	}
-	return mul * sz
+	return "formatClass(" + strconv.Itoa(int(fc)) + ")"
 }
+
+func pixelFormatClass(format uint32) (formatClass, bool) {
+	switch format {
+	case gl.RED, gl.GREEN, gl.BLUE, gl.RG, gl.RGB, gl.BGR, gl.RGBA, gl.BGRA:
+		return classColor, true
+	case gl.RED_INTEGER, gl.GREEN_INTEGER, gl.BLUE_INTEGER, gl.RG_INTEGER, gl.RGB_INTEGER, gl.BGR_INTEGER, gl.RGBA_INTEGER, gl.BGRA_INTEGER:
+		return classInteger, true
+	case gl.DEPTH_COMPONENT:
+		return classDepth, true
+	case gl.STENCIL_INDEX:
+		return classStencil, true
+	case gl.DEPTH_STENCIL:
+		return classDepthStencil, true
+	}
+	return 0, false
+}
+
+func internalFormatClass(internalFormat int32) (formatClass, bool) {
+	switch internalFormat {
+	case gl.RED, gl.RG, gl.RGB, gl.RGBA,
+		gl.R8, gl.R8_SNORM, gl.R16, gl.R16_SNORM, gl.RG8, gl.RG8_SNORM, gl.RG16, gl.RG16_SNORM,
+		gl.R3_G3_B2, gl.RGB4, gl.RGB5, gl.RGB565, gl.RGB8, gl.RGB8_SNORM, gl.RGB10, gl.RGB12, gl.RGB16, gl.RGB16_SNORM,
+		gl.RGBA2, gl.RGBA4, gl.RGB5_A1, gl.RGBA8, gl.RGBA8_SNORM, gl.RGB10_A2, gl.RGBA12, gl.RGBA16, gl.RGBA16_SNORM,
+		gl.SRGB8, gl.SRGB8_ALPHA8, gl.R16F, gl.RG16F, gl.RGB16F, gl.RGBA16F, gl.R32F, gl.RG32F, gl.RGB32F, gl.RGBA32F,
+		gl.R11F_G11F_B10F, gl.RGB9_E5:
+		return classColor, true
+	case gl.R8I, gl.R8UI, gl.R16I, gl.R16UI, gl.R32I, gl.R32UI, gl.RG8I, gl.RG8UI, gl.RG16I, gl.RG16UI, gl.RG32I, gl.RG32UI,
+		gl.RGB8I, gl.RGB8UI, gl.RGB16I, gl.RGB16UI, gl.RGB32I, gl.RGB32UI, gl.RGBA8I, gl.RGBA8UI, gl.RGBA16I, gl.RGBA16UI,
+		gl.RGBA32I, gl.RGBA32UI, gl.RGB10_A2UI:
+		return classInteger, true
+	case gl.DEPTH_COMPONENT, gl.DEPTH_COMPONENT16, gl.DEPTH_COMPONENT24, gl.DEPTH_COMPONENT32, gl.DEPTH_COMPONENT32F:
+		return classDepth, true
+	case gl.STENCIL_INDEX, gl.STENCIL_INDEX8:
+		return classStencil, true
+	case gl.DEPTH_STENCIL, gl.DEPTH24_STENCIL8, gl.DEPTH32F_STENCIL8:
+		return classDepthStencil, true
+	}
+	return 0, false
+}
+
 func assertImgSameSize[T any](cfg TextureImgConfig, data []T) error {
-	sz := cfg.PixelSize() * cfg.Width * cfg.Height
 	bufSize := len(data) * int(unsafe.Sizeof(data[0])) // If you are getting panic here please use nil as data.
-	if sz != bufSize {
+	pixSize, ok := cfg.pixelSize()
+	if !ok {
+		// Unknown pixel size, infer it from T. Buffer must hold a whole number of pixels.
+		npix := cfg.Width * cfg.Height
+		if npix <= 0 || bufSize%npix != 0 || bufSize < npix {
+			return errors.New("data size not a multiple of image pixel count")
+		}
+		return nil
+	}
+	if pixSize*cfg.Width*cfg.Height != bufSize {
 		return errors.New("data size not match to be allocated")
 	}
 	return nil
@@ -394,6 +1141,9 @@ func assertImgSameSize[T any](cfg TextureImgConfig, data []T) error {
 func NewTextureFromImage[T any](cfg TextureImgConfig, data []T) (Texture, error) {
 	var outTexture uint32
 	var ptr unsafe.Pointer = nil
+	if err := cfg.Validate(); err != nil {
+		return Texture{}, err
+	}
 	if data != nil {
 		if err := assertImgSameSize(cfg, data); err != nil {
 			return Texture{}, err
@@ -405,17 +1155,34 @@ func NewTextureFromImage[T any](cfg TextureImgConfig, data []T) (Texture, error)
 		rid:    outTexture,
 		target: uint32(cfg.Type),
 		unit:   uint32(gl.TEXTURE0 + cfg.TextureUnit),
+		cfg:    &cfg,
 	}
 	tex.Bind(cfg.TextureUnit)
 
 	internalFormat := zdefault(cfg.InternalFormat, int32(cfg.Format))
+	restore := tightPixelStore(gl.UNPACK_ALIGNMENT)
 	gl.TexImage2D(tex.target, cfg.Level, internalFormat, int32(cfg.Width), int32(cfg.Height),
 		cfg.Border, cfg.Format, cfg.Xtype, ptr)
+	restore()
 	// Use default values since OpenGL does not do sane defaults: https://medium.com/@daniel.coady/compute-shaders-in-opengl-4-3-d1c741998c03
 	gl.TexParameteri(tex.target, gl.TEXTURE_MAG_FILTER, zdefault(cfg.MagFilter, gl.NEAREST))
 	gl.TexParameteri(tex.target, gl.TEXTURE_MIN_FILTER, zdefault(cfg.MinFilter, gl.NEAREST))
 	gl.TexParameteri(tex.target, gl.TEXTURE_WRAP_S, zdefault(cfg.Wrap, gl.REPEAT))
 	gl.TexParameteri(tex.target, gl.TEXTURE_WRAP_T, zdefault(cfg.Wrap, gl.REPEAT))
+	if cfg.Wrap == gl.CLAMP_TO_BORDER {
+		gl.TexParameterfv(tex.target, gl.TEXTURE_BORDER_COLOR, &cfg.BorderColor[0])
+	}
+	if cfg.CompareMode != 0 {
+		gl.TexParameteri(tex.target, gl.TEXTURE_COMPARE_MODE, cfg.CompareMode)
+	}
+	if cfg.CompareFunc != 0 {
+		gl.TexParameteri(tex.target, gl.TEXTURE_COMPARE_FUNC, cfg.CompareFunc)
+	}
+	switch class, _ := internalFormatClass(internalFormat); class {
+	case classDepth, classDepthStencil, classStencil:
+		// Depth and stencil textures can be sampled or attached but are not valid image unit formats.
+		return tex, Err()
+	}
 
 	// For following call: format specifies the format that is to be used when performing
 	// formatted stores into the image from shaders. format must be compatible with the
@@ -425,31 +1192,167 @@ func NewTextureFromImage[T any](cfg TextureImgConfig, data []T) (Texture, error)
 	return tex, Err()
 }
 
-// SetImage2D sets an existing texture's values on the GPU.
+// SetImage2D sets an existing texture's values on the GPU. Zero valued size, level
+// and format fields of cfg are taken from the texture's [Texture.Config], non-zero
+// fields must match it. Use [Texture.Resize] to change the texture's size.
 func SetImage2D[T any](tex Texture, cfg TextureImgConfig, data []T) error {
+	cfg, err := tex.imageConfig(cfg)
+	if err != nil {
+		return err
+	}
 	var ptr unsafe.Pointer = nil
 	if data != nil {
+		if err := assertImgSameSize(cfg, data); err != nil {
+			return err
+		}
 		ptr = unsafe.Pointer(&data[0])
 	}
 	internalFormat := zdefault(cfg.InternalFormat, int32(cfg.Format))
 	gl.TextureBarrier()
-	gl.TexImage2D(tex.unit, cfg.Level, internalFormat,
+	gl.BindTexture(tex.target, tex.rid)
+	defer tightPixelStore(gl.UNPACK_ALIGNMENT)()
+	gl.TexImage2D(tex.target, cfg.Level, internalFormat,
 		int32(cfg.Width), int32(cfg.Height), cfg.Border, cfg.Format, cfg.Xtype, ptr)
 	return Err()
 }
 
+// GetImage reads the texture's image into dst. Zero valued size, level and format
+// fields of cfg are taken from the texture's [Texture.Config], non-zero fields must
+// match it so the zero TextureImgConfig may be passed in to read back the whole image.
+// The size of dst must match the image size. If the pixel size can not be determined
+// from the config dst must hold a whole number of pixels.
 func GetImage[T any](dst []T, tex Texture, cfg TextureImgConfig) error {
 	if len(dst) == 0 {
 		return errors.New("dst cannot be nil or zero length")
 	}
+	cfg, err := tex.imageConfig(cfg)
+	if err != nil {
+		return err
+	}
 	if err := assertImgSameSize(cfg, dst); err != nil {
 		return err
 	}
+	return getImage(dst, tex, cfg)
+}
+
+// GetImageInto is like [GetImage] but dst may be larger than the image, in which
+// case only the start of dst is written to. The pixel size must be known from
+// the config's Format and Xtype.
+func GetImageInto[T any](dst []T, tex Texture, cfg TextureImgConfig) error {
+	if len(dst) == 0 {
+		return errors.New("dst cannot be nil or zero length")
+	}
+	cfg, err := tex.imageConfig(cfg)
+	if err != nil {
+		return err
+	}
+	pixSize, ok := cfg.pixelSize()
+	if !ok {
+		return errors.New("unknown pixel size for texture format and xtype")
+	} else if len(dst)*elemSize[T]() < pixSize*cfg.Width*cfg.Height {
+		return errors.New("dst too small to hold image")
+	}
+	return getImage(dst, tex, cfg)
+}
+
+// getImage reads the texture's image described by cfg into dst. The size of dst must have been checked.
+func getImage[T any](dst []T, tex Texture, cfg TextureImgConfig) error {
 	gl.TextureBarrier()
+	gl.BindTexture(tex.target, tex.rid)
+	defer tightPixelStore(gl.PACK_ALIGNMENT)()
 	gl.GetTexImage(tex.target, cfg.Level, cfg.Format, cfg.Xtype, unsafe.Pointer(&dst[0]))
 	return Err()
 }
 
+// ReadPixel reads the RGBA color of the pixel at x, y of the framebuffer bound for reading.
+// x, y are relative to the top left corner of the viewport, same as window system
+// cursor positions, and are flipped to the bottom left origin used by OpenGL.
+// ReadPixel is useful for picking objects rendered with a unique color.
+func ReadPixel(x, y int) (r, g, b, a uint8, err error) {
+	glx, gly, err := viewportPixel(x, y)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	var rgba [4]uint8
+	gl.ReadPixels(glx, gly, 1, 1, gl.RGBA, gl.UNSIGNED_BYTE, unsafe.Pointer(&rgba[0]))
+	return rgba[0], rgba[1], rgba[2], rgba[3], Err()
+}
+
+// ReadPixelUint32 reads the red component of the pixel at x, y of an unsigned
+// integer framebuffer attachment such as one with GL_R32UI internal format.
+// Coordinates are interpreted as in [ReadPixel]. ReadPixelUint32 is useful
+// for picking objects rendered with their ID.
+func ReadPixelUint32(x, y int) (uint32, error) {
+	glx, gly, err := viewportPixel(x, y)
+	if err != nil {
+		return 0, err
+	}
+	var id uint32
+	gl.ReadPixels(glx, gly, 1, 1, gl.RED_INTEGER, gl.UNSIGNED_INT, unsafe.Pointer(&id))
+	return id, Err()
+}
+
+// viewportPixel converts x, y coordinates relative to the top left of the viewport
+// to OpenGL window coordinates and checks they lie within the viewport.
+func viewportPixel(x, y int) (glx, gly int32, err error) {
+	var viewport [4]int32 // x, y, width, height.
+	var p runtime.Pinner
+	p.Pin(&viewport)
+	defer p.Unpin()
+	gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+	if x < 0 || y < 0 || x >= int(viewport[2]) || y >= int(viewport[3]) {
+		return 0, 0, errors.New("pixel outside viewport")
+	}
+	return viewport[0] + int32(x), viewport[1] + viewport[3] - 1 - int32(y), nil
+}
+
+// PushProgram records the currently used program and returns a function that
+// restores it. It lets helpers that call glUseProgram leave the caller's state untouched:
+//
+//	defer glgl.PushProgram()()
+func PushProgram() (restore func()) {
+	prog := uint32(getInteger(gl.CURRENT_PROGRAM))
+	return func() { gl.UseProgram(prog) }
+}
+
+// PushVertexArray records the currently bound vertex array object and returns
+// a function that restores it. See [PushProgram].
+func PushVertexArray() (restore func()) {
+	vao := uint32(getInteger(gl.VERTEX_ARRAY_BINDING))
+	return func() { gl.BindVertexArray(vao) }
+}
+
+// PushActiveTexture records the active texture unit and the 2D texture bound to it
+// and returns a function that restores both. See [PushProgram].
+func PushActiveTexture() (restore func()) {
+	unit := uint32(getInteger(gl.ACTIVE_TEXTURE))
+	tex := uint32(getInteger(gl.TEXTURE_BINDING_2D))
+	return func() {
+		gl.ActiveTexture(unit)
+		gl.BindTexture(gl.TEXTURE_2D, tex)
+	}
+}
+
+// tightPixelStore sets the pixel storage alignment pname, gl.PACK_ALIGNMENT or
+// gl.UNPACK_ALIGNMENT, to 1 so that pixel rows are tightly packed as expected by the
+// image size checks and returns a function that restores the previous alignment.
+// The GL default alignment of 4 pads rows of RGB and RED byte images.
+func tightPixelStore(pname uint32) (restore func()) {
+	prev := getInteger(pname)
+	gl.PixelStorei(pname, 1)
+	return func() { gl.PixelStorei(pname, prev) }
+}
+
+// getInteger returns the single integer value of the GL state variable pname.
+func getInteger(pname uint32) int32 {
+	var v int32
+	var p runtime.Pinner
+	p.Pin(&v)
+	defer p.Unpin()
+	gl.GetIntegerv(pname, &v)
+	return v
+}
+
 // ClearErrors clears all of OpenGL's errors in it's log.
 func ClearErrors() {
 	i := 0
@@ -463,6 +1366,8 @@ func ClearErrors() {
 
 // Err returns a non-nil glErrors if errors are foudn in OpenGL's GetError buffer.
 // After a call to Err no more errors should be returned until the next GL call.
+// If the error buffer never clears because there is no current context
+// [ErrNoCurrentContext] is returned.
 func Err() error {
 	code := gl.GetError()
 	if code == gl.NO_ERROR {
@@ -476,6 +1381,10 @@ func Err() error {
 		}
 		errs = append(errs, glError(code))
 		if len(errs) > 61 {
+			if gl.GetString(gl.VERSION) == nil {
+				// GetError never clears without a current context.
+				return ErrNoCurrentContext
+			}
 			lastIdx := len(errs) - 1
 			return fmt.Errorf("possible forever loop in Err. Context may be terminated. errs[0]=%v, errs[%d]=%v(%d)", errs[0].String(), lastIdx, errs[lastIdx].String(), uint32(errs[lastIdx]))
 		}
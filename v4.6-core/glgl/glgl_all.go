@@ -2,7 +2,10 @@ package glgl
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"unsafe"
 )
 
 type WindowConfig struct {
@@ -13,15 +16,63 @@ type WindowConfig struct {
 	OpenGLProfile int // Use [ProfileCore], [ProfileCompat], [ProfileAny].
 	ForwardCompat bool
 	Width, Height int
-	HideWindow    bool // Set glfw.Visible to false
-	DebugLog      *slog.Logger
+	HideWindow    bool         // Set glfw.Visible to false
+	DebugLog      *slog.Logger // If non-nil GL debug output is logged to DebugLog.
+}
+
+// ContextInfo describes the running OpenGL implementation and some of its limits.
+// Use [QueryContextInfo] to acquire it.
+type ContextInfo struct {
+	Vendor      string
+	Renderer    string
+	Version     string
+	GLSLVersion string
+	// MaxComputeInvocations is the maximum product of the local work group
+	// sizes of a compute shader. See [MaxComputeInvocations].
+	MaxComputeInvocations int
+	// MaxWorkGroupCount is the maximum number of work groups that may be dispatched
+	// in each dimension. See [MaxComputeWorkGroupCount].
+	MaxWorkGroupCount [3]int
+	// MaxWorkGroupSize is the maximum local work group size in each dimension.
+	// See [MaxComputeWorkGroupSize].
+	MaxWorkGroupSize [3]int
+	// MaxTextureUnits is the number of texture units accessible from the fragment shader.
+	MaxTextureUnits int
+	// MaxCombinedTextureUnits is the number of texture units accessible from all shader stages combined.
+	MaxCombinedTextureUnits int
+	// MaxImageUnits is the number of image units available for image load/store.
+	MaxImageUnits int
+	// MaxShaderStorageBufferBindings is the number of SSBO binding points.
+	MaxShaderStorageBufferBindings int
 }
 
 type Program struct {
 	rid uint32
 }
 
+// CompileFlags modify the behavior of program compilation. See [CompileProgramWithFlags].
+type CompileFlags uint32
+
+const (
+	// CompileFlagForceVersion prepends a `#version 430` directive to each shader
+	// stage source whose first non-blank line is not a #version directive.
+	CompileFlagForceVersion CompileFlags = 1 << iota
+	// CompileFlagDumpSource appends the final source of each shader stage, as
+	// passed to the GL after all source modifications, with line numbers to the
+	// error returned when compilation or linking fails.
+	CompileFlagDumpSource
+)
+
+// forcedVersionDirective is prepended to shader stages by [CompileFlagForceVersion].
+const forcedVersionDirective = "#version 430\n"
+
+// CompileProgram compiles and links the shader stages present in ss and returns the resulting program.
 func CompileProgram(ss ShaderSource) (prog Program, err error) {
+	return CompileProgramWithFlags(ss, 0)
+}
+
+// CompileProgramWithFlags is like [CompileProgram] but modifies compilation according to flags.
+func CompileProgramWithFlags(ss ShaderSource, flags CompileFlags) (prog Program, err error) {
 	if ss.Compute != "" && (ss.Fragment != "" || ss.Vertex != "") {
 		return Program{}, errors.New("cannot compile compute and frag/vertex together")
 	}
@@ -31,18 +82,161 @@ func CompileProgram(ss ShaderSource) (prog Program, err error) {
 		}
 		return Program{}, errors.New("empty program")
 	}
+	if flags&CompileFlagForceVersion != 0 {
+		// #version must be the first directive so this runs before any other source modification.
+		ss.Vertex = forceVersion(ss.Vertex)
+		ss.Fragment = forceVersion(ss.Fragment)
+		ss.Compute = forceVersion(ss.Compute)
+	}
 	prog, err = compileSources(ss)
+	if err != nil && flags&CompileFlagDumpSource != 0 {
+		err = fmt.Errorf("%w\n%s", err, dumpSources(ss))
+	}
 	return prog, err
 }
 
+// CompileCombined parses a file in the format read by [ParseCombined] and
+// compiles the resulting program. Unlike calling [ParseCombined] followed by
+// [CompileProgram] the parsed stage sources are not copied into strings,
+// which reduces peak memory usage when compiling large generated programs.
+func CompileCombined(r io.Reader) (Program, error) {
+	cs, err := parseCombined(r, 0)
+	if err != nil {
+		return Program{}, err
+	}
+	// Parsed sources are never modified so it is safe to alias them as strings.
+	return CompileProgram(ShaderSource{
+		Vertex:   bytesAsString(cs.vertex),
+		Fragment: bytesAsString(cs.fragment),
+		Compute:  bytesAsString(cs.compute),
+		Include:  bytesAsString(cs.include),
+	})
+}
+
+// bytesAsString returns a string that shares memory with b.
+// b must not be modified while the string is in use.
+func bytesAsString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(&b[0], len(b))
+}
+
+// forceVersion prepends a #version directive to src if its first directive, after
+// leading whitespace and comments, is not a #version directive. Empty sources are returned as is.
+func forceVersion(src string) string {
+	if src == "" || versionDirectiveEnd(src) >= 0 {
+		return src
+	}
+	return forcedVersionDirective + src
+}
+
 type Type uint32
 
+// DispatchSizeFor returns the number of work groups of size localSize needed
+// to process items work items, which is the ceiling of items/localSize.
+// Compute shaders dispatched with this value must guard against out of range
+// invocations since the last work group may be partially filled.
+func DispatchSizeFor(items, localSize int) int {
+	if localSize <= 0 {
+		panic("non-positive local size")
+	} else if items <= 0 {
+		return 0
+	}
+	return (items + localSize - 1) / localSize
+}
+
+// SuggestWorkGroup suggests a local work group size and the number of work groups
+// to dispatch for a one dimensional compute problem of totalItems work items given
+// the limits in info. Limits with a zero value are replaced by the minimum
+// values required by the OpenGL 4.3 specification.
+//
+// SuggestWorkGroup is a heuristic: it favors a local size of 64 invocations, which
+// is a multiple of the SIMD width of most GPUs, and shrinks it for small problems.
+// The returned local size must be declared by the compute shader's layout qualifier.
+// If the number of work groups exceeds the X dimension limit work groups are spread
+// over the Y dimension, so the shader should compute the item index from
+// gl_GlobalInvocationID and gl_NumWorkGroups and guard against out of range items.
+func (info ContextInfo) SuggestWorkGroup(totalItems int) (local, groups [3]int) {
+	const preferredLocal = 64
+	maxInvocations := info.MaxComputeInvocations
+	if maxInvocations <= 0 {
+		maxInvocations = 1024
+	}
+	maxSizeX := info.MaxWorkGroupSize[0]
+	if maxSizeX <= 0 {
+		maxSizeX = 1024
+	}
+	maxCountX := info.MaxWorkGroupCount[0]
+	if maxCountX <= 0 {
+		maxCountX = 65535
+	}
+	localX := min(preferredLocal, maxInvocations, maxSizeX)
+	if totalItems < localX {
+		// Smallest power of two that fits all items.
+		localX = 1
+		for localX < totalItems {
+			localX *= 2
+		}
+	}
+	local = [3]int{localX, 1, 1}
+	groupsX := DispatchSizeFor(totalItems, localX)
+	if groupsX == 0 {
+		return local, groups // No work to dispatch.
+	}
+	groupsY := 1
+	if groupsX > maxCountX {
+		groupsY = DispatchSizeFor(groupsX, maxCountX)
+		groupsX = DispatchSizeFor(groupsX, groupsY)
+	}
+	return local, [3]int{groupsX, groupsY, 1}
+}
+
 // VertexArray ties data layout with vertex buffer(s).
 // Is aware of data layout via VertexAttribPointer* calls.
 // Vertex array parameters are client state, that is to say the GPU is unaware of it.
 // Loosely speaking, a vertex array
 type VertexArray struct {
 	rid uint32
+	// attribs holds the attributes added to the vertex array. It is shared
+	// between copies of the VertexArray so that all copies observe additions.
+	attribs *[]vertexAttrib
+}
+
+// vertexAttrib is an attribute layout recorded by a VertexArray.
+type vertexAttrib struct {
+	layout AttribLayout
+	vbo    bufferObject
+	loc    uint32
+	// integer is set for attributes read as integers via glVertexAttribIPointer.
+	integer bool
+}
+
+// ActiveAttribute describes an active vertex attribute of a linked program.
+// See [Program.ActiveAttributes].
+type ActiveAttribute struct {
+	// Name is the identifier of the attribute in the shader source code without null terminator.
+	Name string
+	// Location is the attribute location or index.
+	Location uint32
+	// GLSLType is the OpenGL enum of the attribute's GLSL type, i.e: gl.FLOAT_VEC3.
+	GLSLType uint32
+	// Size is the number of elements of array attributes. It is 1 for non-array attributes.
+	Size int
+}
+
+// ImageUniform describes an image uniform declared in shader source code.
+// See [ShaderSource.ImageUniforms].
+type ImageUniform struct {
+	// Name is the identifier of the uniform in the shader source code without null terminator.
+	Name string
+	// Format is the image format layout qualifier, i.e: "r32f", "rgba8ui".
+	// It is empty if the declaration has no format qualifier.
+	Format string
+	// Binding is the image unit declared by the `binding` layout qualifier or -1 if not declared.
+	Binding int
+	// Type is the GLSL type of the uniform, i.e: "image2D", "uimage2D".
+	Type string
 }
 
 // AttribLayout is a low level configuration struct
@@ -65,6 +259,8 @@ type AttribLayout struct {
 	// 3 floats packed at each attribute location.
 	Packing int
 	// Stride is the distance in bytes between attributes in the buffer.
+	// If zero the attributes are assumed to be tightly packed and
+	// the stride is calculated as Packing*Type.Size().
 	Stride int
 	// Offset is the starting offset with which to start
 	// traversing the vertex buffer.
@@ -101,18 +297,60 @@ type AttribLayout struct {
 // COPY is used when a buffer object is used to pass data from one place in OpenGL to another.
 type BufferUsage uint32
 
+// bufferObject holds the state common to all buffer types.
+type bufferObject struct {
+	// Renderer ID. If using OpenGL is the id set on buffer creation.
+	rid uint32
+	// Target the buffer is bound to, i.e: gl.ARRAY_BUFFER.
+	target uint32
+	usage  BufferUsage
+	// Size in bytes of buffer on creation.
+	sz int
+}
+
+// Buffer is a buffer object holding elements of type T. It is bound to the
+// target it was created with, i.e: gl.ARRAY_BUFFER, gl.SHADER_STORAGE_BUFFER or gl.UNIFORM_BUFFER.
+// Use [NewBuffer] to create a Buffer.
+type Buffer[T any] struct {
+	obj bufferObject
+}
+
 // VertexBuffer contains bytes, no information on the layout or type.
 // Buffer objects are said to be "server state", compared to vertex array parameters as "client state".
 type VertexBuffer struct {
-	// Renderer ID. If using OpenGL is the id set on buffer creation.
-	rid uint32
+	obj bufferObject
+}
+
+// UniformBuffer is a buffer object backing a GLSL uniform block. Commonly referred to as UBO.
+type UniformBuffer struct {
+	obj  bufferObject
+	base uint32
+}
+
+type UniformBufferConfig struct {
+	// Base is the binding point for the buffer. Uniform blocks in a shader are
+	// bound to it with the layout parameter `binding` or with [Program.UniformBlockBinding]:
+	// 	layout(std140, binding = 0) uniform Camera {
+	// 		mat4 viewProj;
+	// 	};
+	Base uint32
+	// MemSize specifies the size in bytes of memory the UniformBuffer should take up.
+	// When creating buffers with [NewUniformBuffer] MemSize will decide the size of the buffer
+	// if the data slice is nil.
+	MemSize uint32
 }
 
 type AccessUsage uint32
 
+// BarrierMask is a bitfield of memory barrier bits passed to [MemoryBarrier].
+// Each bit selects which kind of memory access after the barrier will reflect
+// data written by shaders prior to the barrier. Bits may be OR'd together, i.e:
+//
+//	glgl.BarrierShaderImageAccess | glgl.BarrierShaderStorage
+type BarrierMask uint32
+
 type IndexBuffer struct {
-	// Renderer ID. If using OpenGL is the id set on buffer creation.
-	rid uint32
+	obj bufferObject
 }
 
 type TextureType uint32
@@ -147,6 +385,17 @@ type TextureImgConfig struct {
 	// how OpenGL is to repeat the texture outside this range.
 	// gl.REPEAT, gl.MIRRORED_REPEAT, gl.CLAMP_TO_EDGE, gl.CLAMP_TO_BORDER.
 	Wrap int32
+	// BorderColor is the RGBA color returned when sampling outside the texture
+	// with Wrap set to gl.CLAMP_TO_BORDER. Ignored for other Wrap values.
+	BorderColor [4]float32
+	// CompareMode sets TEXTURE_COMPARE_MODE of depth textures. Set to
+	// gl.COMPARE_REF_TO_TEXTURE to sample the texture with a sampler2DShadow.
+	// If not set the GL default gl.NONE is used.
+	CompareMode int32
+	// CompareFunc sets TEXTURE_COMPARE_FUNC used when CompareMode is
+	// gl.COMPARE_REF_TO_TEXTURE, i.e: gl.LEQUAL, gl.LESS, gl.GREATER.
+	// If not set the GL default gl.LEQUAL is used.
+	CompareFunc int32
 
 	// Specifies a token indicating the type of access that will be performed on the image.
 	Access AccessUsage
@@ -167,9 +416,9 @@ type TextureImgConfig struct {
 
 // ShaderStorageBuffer is a generic buffer object. Commonly referred to as SSBO.
 type ShaderStorageBuffer struct {
-	id    uint32
-	usage AccessUsage
-	sz    int
+	obj    bufferObject
+	access AccessUsage
+	base   uint32
 }
 
 type ShaderStorageBufferConfig struct {
@@ -30,6 +30,10 @@ func MaxComputeWorkGroupSize() (Wsx, Wsy, Wsz int) {
 
 func Version() string { return errNoCgo.Error() }
 
+func QueryContextInfo() ContextInfo {
+	return ContextInfo{Version: Version()}
+}
+
 func EnableDebugOutput(log *slog.Logger) {}
 
 func compileSources(ss ShaderSource) (program Program, err error) {
@@ -22,3 +22,46 @@ func TestWindow(t *testing.T) {
 	term()
 	_ = window
 }
+
+func TestDispatchSizeFor(t *testing.T) {
+	for _, test := range []struct {
+		items, local, want int
+	}{
+		{items: 0, local: 8, want: 0},
+		{items: 1, local: 8, want: 1},
+		{items: 8, local: 8, want: 1},
+		{items: 9, local: 8, want: 2},
+		{items: 1000, local: 1, want: 1000},
+		{items: 1000, local: 64, want: 16},
+	} {
+		got := glgl.DispatchSizeFor(test.items, test.local)
+		if got != test.want {
+			t.Errorf("DispatchSizeFor(%d, %d) want %d, got %d", test.items, test.local, test.want, got)
+		}
+	}
+}
+
+func TestContextInfoSuggestWorkGroup(t *testing.T) {
+	limited := glgl.ContextInfo{MaxComputeInvocations: 32, MaxWorkGroupCount: [3]int{10, 10, 10}}
+	for _, test := range []struct {
+		info       glgl.ContextInfo
+		items      int
+		wantLocal  [3]int
+		wantGroups [3]int
+	}{
+		{items: 0, wantLocal: [3]int{1, 1, 1}},
+		{items: 5, wantLocal: [3]int{8, 1, 1}, wantGroups: [3]int{1, 1, 1}},
+		{items: 64, wantLocal: [3]int{64, 1, 1}, wantGroups: [3]int{1, 1, 1}},
+		{items: 1000, wantLocal: [3]int{64, 1, 1}, wantGroups: [3]int{16, 1, 1}},
+		{info: limited, items: 100, wantLocal: [3]int{32, 1, 1}, wantGroups: [3]int{4, 1, 1}},
+		{info: limited, items: 1000, wantLocal: [3]int{32, 1, 1}, wantGroups: [3]int{8, 4, 1}},
+	} {
+		local, groups := test.info.SuggestWorkGroup(test.items)
+		if local != test.wantLocal || groups != test.wantGroups {
+			t.Errorf("SuggestWorkGroup(%d) want %v,%v, got %v,%v", test.items, test.wantLocal, test.wantGroups, local, groups)
+		}
+		if invocations := local[0] * local[1] * local[2] * groups[0] * groups[1] * groups[2]; invocations < test.items {
+			t.Errorf("SuggestWorkGroup(%d) dispatches only %d invocations", test.items, invocations)
+		}
+	}
+}
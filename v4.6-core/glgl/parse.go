@@ -4,7 +4,11 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 // Vertex and Fragment are null terminated strings with source code.
@@ -36,6 +40,44 @@ type ShaderSource struct {
 //
 // [The Cherno]: https://www.youtube.com/watch?v=2pv0Fbo-7ms&list=PLlrATfBNZ98foTJPJ_Ev03o2oq3-GGOS2&index=9&t=724s&ab_channel=TheCherno
 func ParseCombined(r io.Reader) (ss ShaderSource, err error) {
+	return ParseCombinedWithFlags(r, 0)
+}
+
+// ParseFlags modify the behavior of [ParseCombinedWithFlags].
+type ParseFlags uint32
+
+const (
+	// ParseFlagPreambleAsInclude treats the text above the first #shader pragma
+	// as shared code which is prepended to every stage, same as the
+	// `includeashead` block. The preamble precedes the `includeashead` block
+	// unless the block starts with a #version directive, which must be the first
+	// directive of a shader, in which case the preamble follows the directive.
+	// Without this flag the preamble is ignored.
+	ParseFlagPreambleAsInclude ParseFlags = 1 << iota
+)
+
+// ParseCombinedWithFlags is like [ParseCombined] but modifies parsing according to flags.
+func ParseCombinedWithFlags(r io.Reader, flags ParseFlags) (ss ShaderSource, err error) {
+	cs, err := parseCombined(r, flags)
+	if err != nil {
+		return ShaderSource{}, err
+	}
+	return ShaderSource{
+		Vertex:   string(cs.vertex),
+		Fragment: string(cs.fragment),
+		Compute:  string(cs.compute),
+		Include:  string(cs.include),
+	}, nil
+}
+
+// combinedSource holds the result of parsing a combined shader file.
+// Non-empty stages are null terminated and have the include block prepended.
+type combinedSource struct {
+	vertex, fragment, compute, include []byte
+}
+
+// parseCombined parses a combined shader file. See [ParseCombinedWithFlags].
+func parseCombined(r io.Reader, flags ParseFlags) (cs combinedSource, err error) {
 	const (
 		shaderNone = iota
 		shaderVertex
@@ -49,6 +91,11 @@ func ParseCombined(r io.Reader) (ss ShaderSource, err error) {
 	fragBuf := bytes.NewBuffer(nil)
 	computeBuf := bytes.NewBuffer(nil)
 	includeBuf := bytes.NewBuffer(nil)
+	preambleBuf := bytes.NewBuffer(nil)
+	if flags&ParseFlagPreambleAsInclude != 0 {
+		// Text above first #shader pragma is merged into the include block after parsing.
+		nothing = preambleBuf
+	}
 	buffers := [shaderNum]*bytes.Buffer{
 		shaderNone:     nothing,
 		shaderVertex:   vertexBuf,
@@ -56,18 +103,21 @@ func ParseCombined(r io.Reader) (ss ShaderSource, err error) {
 		shaderCompute:  computeBuf,
 		shaderHeader:   includeBuf,
 	}
+	// declared stores the pragma value with which each stage was declared.
+	var declared [shaderNum]string
 	scanner := bufio.NewScanner(r)
 	currentShader := shaderNone
 	for scanner.Scan() {
-		line := scanner.Bytes()
-		if currentShader != shaderNone && !bytes.HasPrefix(bytes.TrimSpace(line), []byte("#shader ")) {
+		// Scanner strips CR from CRLF line endings but not lone trailing CRs.
+		line := bytes.TrimRight(scanner.Bytes(), "\r")
+		got := bytes.Fields(line)
+		if len(got) == 0 || string(got[0]) != "#shader" {
 			buffers[currentShader].Write(line)
 			buffers[currentShader].WriteByte('\n')
 			continue
 		}
-		got := bytes.Fields(line)
 		if len(got) != 2 {
-			continue
+			return combinedSource{}, errors.New("malformed #shader pragma, expected `#shader <stage>`: " + string(line))
 		}
 		switch string(got[1]) {
 		case "includeashead":
@@ -79,30 +129,280 @@ func ParseCombined(r io.Reader) (ss ShaderSource, err error) {
 		case "compute":
 			currentShader = shaderCompute
 		default:
-			return ShaderSource{}, errors.New("unexpected #shader pragma value:" + string(got[1]))
+			return combinedSource{}, errors.New("unexpected #shader pragma value:" + string(got[1]))
+		}
+		declared[currentShader] = string(got[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return combinedSource{}, err
+	}
+	for _, stage := range [...]int{shaderVertex, shaderFragment, shaderCompute} {
+		if declared[stage] != "" && len(bytes.TrimSpace(buffers[stage].Bytes())) == 0 {
+			return combinedSource{}, errors.New("#shader " + declared[stage] + " declared but no source followed")
 		}
 	}
 	isrc := includeBuf.Bytes()
-	var vsrc, fsrc, csrc []byte
-	if vertexBuf.Len() > 0 {
-		vsrc = append(vsrc, isrc...)
-		vertexBuf.WriteByte(0)
-		vsrc = append(vsrc, vertexBuf.Bytes()...)
-	}
-	if computeBuf.Len() > 0 {
-		csrc = append(csrc, isrc...)
-		computeBuf.WriteByte(0)
-		csrc = append(csrc, computeBuf.Bytes()...)
-	}
-	if fragBuf.Len() > 0 {
-		fsrc = append(fsrc, isrc...)
-		fragBuf.WriteByte(0)
-		fsrc = append(fsrc, fragBuf.Bytes()...)
+	if preamble := preambleBuf.Bytes(); len(preamble) > 0 {
+		at := max(versionDirectiveEnd(string(isrc)), 0)
+		src := make([]byte, 0, len(isrc)+len(preamble))
+		src = append(src, isrc[:at]...)
+		src = append(src, preamble...)
+		isrc = append(src, isrc[at:]...)
 	}
-	return ShaderSource{
-		Vertex:   string(vsrc),
-		Fragment: string(fsrc),
-		Compute:  string(csrc),
-		Include:  string(isrc),
-	}, scanner.Err()
+	vsrc := stageSource(isrc, vertexBuf)
+	fsrc := stageSource(isrc, fragBuf)
+	csrc := stageSource(isrc, computeBuf)
+	return combinedSource{
+		vertex:   vsrc,
+		fragment: fsrc,
+		compute:  csrc,
+		include:  isrc,
+	}, nil
+}
+
+// versionDirectiveEnd returns the index just past the line of the #version directive
+// of src if it is the first directive of src, skipping leading whitespace and comments.
+// It returns -1 if src does not start with a #version directive.
+func versionDirectiveEnd(src string) int {
+	i := 0
+	for i < len(src) {
+		rest := src[i:]
+		switch {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\r' || rest[0] == '\n':
+			i++
+		case strings.HasPrefix(rest, "//"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				return -1
+			}
+			i += end + 1
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return -1
+			}
+			i += end + 4
+		case strings.HasPrefix(rest, "#version"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				return len(src)
+			}
+			return i + end + 1
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+// stageSource returns the null terminated contents of stage prepended with include.
+// The contents of stage are returned without copying if include is empty.
+func stageSource(include []byte, stage *bytes.Buffer) []byte {
+	if stage.Len() == 0 {
+		return nil
+	}
+	stage.WriteByte(0)
+	if len(include) == 0 {
+		return stage.Bytes()
+	}
+	src := make([]byte, 0, len(include)+stage.Len())
+	src = append(src, include...)
+	return append(src, stage.Bytes()...)
+}
+
+// WriteCombined writes ss to w in the combined #shader pragma format read by
+// [ParseCombined]. The include block is written first under an `includeashead`
+// pragma followed by each non-empty stage. Include text prepended to a stage and
+// the stage's null terminator are stripped so that parsing the output yields ss.
+// WriteCombined performs no calls to the GL.
+func (ss ShaderSource) WriteCombined(w io.Writer) error {
+	stages := [...]struct {
+		pragma string
+		src    string
+	}{
+		{pragma: "includeashead", src: ss.Include},
+		{pragma: "vertex", src: ss.stageBody(ss.Vertex)},
+		{pragma: "fragment", src: ss.stageBody(ss.Fragment)},
+		{pragma: "compute", src: ss.stageBody(ss.Compute)},
+	}
+	for _, stage := range stages {
+		if stage.src == "" {
+			continue
+		}
+		_, err := io.WriteString(w, "#shader "+stage.pragma+"\n"+stage.src)
+		if err != nil {
+			return err
+		}
+		if !strings.HasSuffix(stage.src, "\n") {
+			_, err = io.WriteString(w, "\n")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// stageBody returns src without the include prefix and null terminator.
+func (ss ShaderSource) stageBody(src string) string {
+	if src == "" {
+		return ""
+	}
+	src = strings.TrimPrefix(src, ss.Include)
+	return strings.TrimSuffix(src, "\x00")
+}
+
+// dumpSources returns the non-empty stage sources of ss with line numbers,
+// each preceded by a header naming the stage.
+func dumpSources(ss ShaderSource) string {
+	var b strings.Builder
+	for _, stage := range [...]struct{ name, src string }{
+		{name: "vertex", src: ss.Vertex},
+		{name: "fragment", src: ss.Fragment},
+		{name: "compute", src: ss.Compute},
+	} {
+		if stage.src == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n--- %s shader source ---", stage.name)
+		lines := strings.Split(strings.TrimSuffix(stage.src, "\x00"), "\n")
+		if lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1] // Trailing newline.
+		}
+		for i, line := range lines {
+			fmt.Fprintf(&b, "\n%5d| %s", i+1, line)
+		}
+	}
+	return b.String()
+}
+
+// imageDecl matches image uniform declarations with a layout qualifier, capturing
+// the layout qualifier list, the image type and the uniform identifier.
+var imageDecl = regexp.MustCompile(`layout\s*\(([^)]*)\)\s*(?:\w+\s+)*?uniform\s+(?:\w+\s+)*?([iu]?image\w+)\s+(\w+)`)
+
+// ImageUniforms returns the image uniforms declared with a layout qualifier in the
+// vertex, fragment and compute stages of ss. Uniforms declared in more than one stage
+// are returned once. ImageUniforms performs no calls to the GL.
+func (ss ShaderSource) ImageUniforms() []ImageUniform {
+	var uniforms []ImageUniform
+	for _, src := range [...]string{ss.Include, ss.Vertex, ss.Fragment, ss.Compute} {
+	DECLS:
+		for _, match := range imageDecl.FindAllStringSubmatch(stripComments(src), -1) {
+			u := ImageUniform{Type: match[2], Name: match[3], Binding: -1}
+			for _, u2 := range uniforms {
+				if u2.Name == u.Name {
+					continue DECLS
+				}
+			}
+			for _, qualifier := range strings.Split(match[1], ",") {
+				key, value, isAssign := strings.Cut(qualifier, "=")
+				key = strings.TrimSpace(key)
+				if !isAssign {
+					u.Format = key
+				} else if key == "binding" {
+					binding, err := strconv.Atoi(strings.TrimSpace(value))
+					if err == nil {
+						u.Binding = binding
+					}
+				}
+			}
+			uniforms = append(uniforms, u)
+		}
+	}
+	return uniforms
+}
+
+// stripComments returns src with its line and block comments removed. Block
+// comments are replaced by a space so the tokens around them stay separated.
+func stripComments(src string) string {
+	if !strings.Contains(src, "/") {
+		return src
+	}
+	var b strings.Builder
+	b.Grow(len(src))
+	for len(src) > 0 {
+		switch {
+		case strings.HasPrefix(src, "//"):
+			end := strings.IndexByte(src, '\n')
+			if end < 0 {
+				return b.String()
+			}
+			src = src[end:]
+		case strings.HasPrefix(src, "/*"):
+			end := strings.Index(src[2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			b.WriteByte(' ')
+			src = src[end+4:]
+		default:
+			b.WriteByte(src[0])
+			src = src[1:]
+		}
+	}
+	return b.String()
+}
+
+// logLocation matches the source string index and line number at the start of
+// driver log lines. Common formats are:
+//
+//	0(12) : error C0000: ...     (NVIDIA)
+//	0:12(5): error: ...          (Mesa)
+//	ERROR: 0:12: ...             (AMD, Intel)
+var logLocation = regexp.MustCompile(`(?m)^(?:ERROR: |WARNING: )?(\d+)(?:\((\d+)\)|:(\d+))`)
+
+// annotateLog appends the source code lines referenced by a shader compiler
+// log to the log with a few lines of context around them. sources are the
+// source strings that were passed to glShaderSource.
+func annotateLog(log string, sources ...string) string {
+	const (
+		contextLines = 2
+		maxLocations = 8
+	)
+	type location struct{ src, line int }
+	var seen []location
+	for _, match := range logLocation.FindAllStringSubmatch(log, -1) {
+		src, _ := strconv.Atoi(match[1])
+		lineStr := match[2]
+		if lineStr == "" {
+			lineStr = match[3]
+		}
+		line, err := strconv.Atoi(lineStr)
+		if err != nil || src < 0 || src >= len(sources) {
+			continue
+		}
+		loc := location{src: src, line: line}
+		dup := false
+		for _, s := range seen {
+			dup = dup || s == loc
+		}
+		if !dup {
+			seen = append(seen, loc)
+		}
+		if len(seen) == maxLocations {
+			break
+		}
+	}
+	if len(seen) == 0 {
+		return log
+	}
+	var b strings.Builder
+	b.WriteString(log)
+	for _, loc := range seen {
+		lines := strings.Split(strings.TrimSuffix(sources[loc.src], "\x00"), "\n")
+		if loc.line < 1 || loc.line > len(lines) {
+			continue
+		}
+		b.WriteString("\n")
+		first := max(loc.line-contextLines, 1)
+		last := min(loc.line+contextLines, len(lines))
+		for i := first; i <= last; i++ {
+			marker := ' '
+			if i == loc.line {
+				marker = '>'
+			}
+			fmt.Fprintf(&b, "\n%c%4d| %s", marker, i, lines[i-1])
+		}
+	}
+	return b.String()
 }
@@ -0,0 +1,72 @@
+package glgl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnnotateLog(t *testing.T) {
+	const src = "#version 430\nvoid main() {\n\tfloat a = 1.0\n\tgl_Position = vec4(a);\n}\n\x00"
+	for _, log := range []string{
+		"0(3) : error C0000: syntax error, unexpected identifier",
+		"0:3(2): error: syntax error, unexpected IDENTIFIER",
+		"ERROR: 0:3: '' : syntax error",
+	} {
+		got := annotateLog(log, src)
+		if !strings.HasPrefix(got, log) {
+			t.Errorf("annotated log does not start with original log: %q", got)
+		}
+		if !strings.Contains(got, ">   3| \tfloat a = 1.0") {
+			t.Errorf("annotated log missing offending line: %q", got)
+		}
+		if !strings.Contains(got, "    1| #version 430") || !strings.Contains(got, "    5| }") {
+			t.Errorf("annotated log missing context lines: %q", got)
+		}
+	}
+	const noLocation = "link error: something went wrong"
+	if got := annotateLog(noLocation, src); got != noLocation {
+		t.Errorf("log without location should be unchanged, got %q", got)
+	}
+}
+
+func TestDumpSources(t *testing.T) {
+	ss := ShaderSource{
+		Vertex:   "#version 430\nvoid main() {}\n\x00",
+		Fragment: "#version 430\nout vec4 c;\nvoid main() { c = vec4(1.0); }\n\x00",
+	}
+	got := dumpSources(ss)
+	for _, want := range []string{
+		"--- vertex shader source ---\n    1| #version 430\n    2| void main() {}",
+		"--- fragment shader source ---\n    1| #version 430\n    2| out vec4 c;\n    3| void main() { c = vec4(1.0); }",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("dump missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "compute") || strings.Contains(got, "\x00") {
+		t.Errorf("dump contains empty stage or null terminator:\n%s", got)
+	}
+}
+
+func TestForceVersion(t *testing.T) {
+	for _, test := range []struct {
+		src       string
+		unchanged bool
+	}{
+		{src: "", unchanged: true},
+		{src: "#version 460\nvoid main() {}\n\x00", unchanged: true},
+		{src: "\n  \t#version 460\nvoid main() {}\n\x00", unchanged: true},
+		{src: "// Comment.\n#version 460\nvoid main() {}\n\x00", unchanged: true},
+		{src: "/* Block\ncomment */\n#version 460\nvoid main() {}\n\x00", unchanged: true},
+		{src: "void main() {}\n\x00"},
+		{src: "// Comment.\nvoid main() {}\n\x00"},
+		{src: "#define A 1\n#version 460\n\x00"},
+	} {
+		got := forceVersion(test.src)
+		if test.unchanged && got != test.src {
+			t.Errorf("%q: want unchanged source, got %q", test.src, got)
+		} else if !test.unchanged && got != forcedVersionDirective+test.src {
+			t.Errorf("%q: want forced #version directive, got %q", test.src, got)
+		}
+	}
+}
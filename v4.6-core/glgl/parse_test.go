@@ -0,0 +1,167 @@
+package glgl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/soypat/glgl/v4.6-core/glgl"
+)
+
+func TestParseCombinedPreamble(t *testing.T) {
+	const src = `#define PI 3.14159
+#shader includeashead
+#version 430
+#shader compute
+void main() {}
+`
+	ss, err := glgl.ParseCombined(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(ss.Compute, "#define PI") {
+		t.Error("preamble should be ignored without ParseFlagPreambleAsInclude")
+	}
+	ss, err = glgl.ParseCombinedWithFlags(strings.NewReader(src), glgl.ParseFlagPreambleAsInclude)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The #version directive must remain the first directive of the shader.
+	const wantInclude = "#version 430\n#define PI 3.14159\n"
+	if ss.Include != wantInclude {
+		t.Errorf("want include %q, got %q", wantInclude, ss.Include)
+	}
+	if !strings.HasPrefix(ss.Compute, wantInclude) {
+		t.Errorf("compute stage missing preamble: %q", ss.Compute)
+	}
+	for _, test := range []struct {
+		src         string
+		wantInclude string
+	}{
+		{ // Comments may precede the #version directive.
+			src:         "#define A 1\n#shader includeashead\n// Header.\n/* Block\ncomment */ #version 430\nint b;\n#shader compute\nvoid main() {}\n",
+			wantInclude: "// Header.\n/* Block\ncomment */ #version 430\n#define A 1\nint b;\n",
+		},
+		{ // No #version directive in include block.
+			src:         "#define A 1\n#shader includeashead\nint b;\n#shader compute\nvoid main() {}\n",
+			wantInclude: "#define A 1\nint b;\n",
+		},
+		{ // Preamble only.
+			src:         "#define A 1\n#shader compute\nvoid main() {}\n",
+			wantInclude: "#define A 1\n",
+		},
+	} {
+		ss, err = glgl.ParseCombinedWithFlags(strings.NewReader(test.src), glgl.ParseFlagPreambleAsInclude)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ss.Include != test.wantInclude {
+			t.Errorf("%q: want include %q, got %q", test.src, test.wantInclude, ss.Include)
+		}
+	}
+}
+
+func TestParseCombinedWhitespace(t *testing.T) {
+	const want = "void main() {}\n\x00"
+	for _, src := range []string{
+		"#shader compute\nvoid main() {}\n",
+		"#shader compute\r\nvoid main() {}\r\n",
+		"#shader  compute\nvoid main() {}\n",
+		"#shader\tcompute\t\r\nvoid main() {}\r\n",
+		"  \t#shader \t compute  \r\nvoid main() {}\r",
+	} {
+		ss, err := glgl.ParseCombined(strings.NewReader(src))
+		if err != nil {
+			t.Errorf("%q: %s", src, err)
+			continue
+		}
+		if ss.Compute != want {
+			t.Errorf("%q: want compute %q, got %q", src, want, ss.Compute)
+		}
+	}
+	_, err := glgl.ParseCombined(strings.NewReader("#shader compute extra\nvoid main() {}\n"))
+	if err == nil {
+		t.Error("expected error for malformed pragma")
+	}
+}
+
+func TestParseCombinedEmptyStage(t *testing.T) {
+	for _, src := range []string{
+		"#shader vertex\nvoid main() {}\n#shader fragment\n",
+		"#shader vertex\n  \n\t\n#shader pixel\nvoid main() {}\n",
+		"#shader compute\n\n",
+	} {
+		_, err := glgl.ParseCombined(strings.NewReader(src))
+		if err == nil {
+			t.Errorf("%q: expected error for empty stage", src)
+		} else if !strings.Contains(err.Error(), "declared but no source followed") {
+			t.Errorf("%q: unexpected error: %s", src, err)
+		}
+	}
+}
+
+func TestShaderSourceWriteCombined(t *testing.T) {
+	for _, src := range []string{
+		"#shader compute\nvoid main() {}\n",
+		"#shader includeashead\n#version 430\n#shader compute\nvoid main() {}\n",
+		"#shader includeashead\n#version 330\n#shader vertex\nvoid main() {\n}\n#shader fragment\nvoid main() {}\n",
+	} {
+		want, err := glgl.ParseCombined(strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf strings.Builder
+		err = want.WriteCombined(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := glgl.ParseCombined(strings.NewReader(buf.String()))
+		if err != nil {
+			t.Fatalf("%q: %s", buf.String(), err)
+		}
+		if got != want {
+			t.Errorf("round trip mismatch:\nwant %q\ngot  %q", want, got)
+		}
+		if buf.String() != src {
+			t.Errorf("want combined output %q, got %q", src, buf.String())
+		}
+	}
+}
+
+func TestCompileCombinedParseError(t *testing.T) {
+	// Parse errors are returned before any calls to the GL.
+	_, err := glgl.CompileCombined(strings.NewReader("#shader geometry\nvoid main() {}\n"))
+	if err == nil || !strings.Contains(err.Error(), "unexpected #shader pragma") {
+		t.Fatalf("expected pragma parse error, got %v", err)
+	}
+}
+
+func TestShaderSourceImageUniforms(t *testing.T) {
+	ss := glgl.ShaderSource{
+		Compute: `#version 430
+layout(local_size_x = 1, local_size_y = 1, local_size_z = 1) in;
+layout(r32f, binding = 0) uniform readonly image2D in_tex;
+layout( binding=2 , rgba8ui ) writeonly uniform uimage2D out_tex;
+layout(binding = 3) uniform image2D no_format;
+uniform sampler2D not_image;
+// layout(r32f, binding = 4) uniform image2D line_commented;
+/* layout(r32f, binding = 5) uniform image2D block_commented; */
+/*
+layout(r32f, binding = 6) uniform image2D multiline_commented;
+*/
+`,
+	}
+	got := ss.ImageUniforms()
+	want := []glgl.ImageUniform{
+		{Name: "in_tex", Format: "r32f", Binding: 0, Type: "image2D"},
+		{Name: "out_tex", Format: "rgba8ui", Binding: 2, Type: "uimage2D"},
+		{Name: "no_format", Format: "", Binding: 3, Type: "image2D"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want %d image uniforms, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("uniform %d: want %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
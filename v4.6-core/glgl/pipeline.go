@@ -0,0 +1,193 @@
+//go:build !tinygo && cgo
+
+package glgl
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// ComputePipeline manages a compute program along with the textures and shader
+// storage buffers it reads and writes, which are referred to by their identifier
+// in the shader source code. Image units, buffer binding points and memory barriers
+// are handled by the pipeline:
+//
+//	cp, err := glgl.NewComputePipeline(ss)
+//	err = cp.AddImage("in_tex", 256, 1)  // layout(r32f, binding = 0) uniform image2D in_tex;
+//	err = cp.AddBuffer("Output", 256*4) // layout(std430, binding = 1) buffer Output { float data[]; };
+//	err = glgl.SetComputeInput(cp, "in_tex", input)
+//	err = cp.Run(256, 1, 1)
+//	err = glgl.GetComputeOutput(cp, "Output", output)
+//
+// Data is transferred with the [SetComputeInput] and [GetComputeOutput] functions
+// since methods can not have type parameters.
+type ComputePipeline struct {
+	prog    Program
+	src     ShaderSource
+	images  map[string]Texture
+	buffers map[string]ShaderStorageBuffer
+}
+
+// NewComputePipeline compiles the compute shader of ss and returns a pipeline with no images or buffers.
+func NewComputePipeline(ss ShaderSource) (*ComputePipeline, error) {
+	if ss.Compute == "" {
+		return nil, errors.New("missing compute shader source")
+	}
+	prog, err := CompileProgram(ss)
+	if err != nil {
+		return nil, err
+	}
+	return &ComputePipeline{
+		prog:    prog,
+		src:     ss,
+		images:  make(map[string]Texture),
+		buffers: make(map[string]ShaderStorageBuffer),
+	}, nil
+}
+
+// Program returns the pipeline's compute program, i.e: for setting uniforms.
+func (cp *ComputePipeline) Program() Program { return cp.prog }
+
+// AddImage creates a width by height texture for the image uniform name and binds
+// it to the image unit the uniform reads. The texture's format is that of the
+// uniform's format layout qualifier, which must be present:
+//
+//	layout(r32f, binding = 0) uniform image2D name;
+func (cp *ComputePipeline) AddImage(name string, width, height int) error {
+	if cp.has(name) {
+		return fmt.Errorf("compute pipeline already has %q", name)
+	}
+	var decl ImageUniform
+	for _, u := range cp.src.ImageUniforms() {
+		if u.Name == name {
+			decl = u
+			break
+		}
+	}
+	if decl.Name == "" {
+		return fmt.Errorf("image uniform %q not declared with a layout qualifier", name)
+	}
+	format, ok := imageFormatQualifiers[decl.Format]
+	if !ok {
+		return fmt.Errorf("image uniform %q has unknown or missing format qualifier %q", name, decl.Format)
+	}
+	unit, ok := cp.prog.imageUnit(name)
+	if !ok {
+		return fmt.Errorf("image uniform %q not active in program", name)
+	}
+	tex, err := NewTextureFromImage[byte](TextureImgConfig{
+		Type:           Texture2D,
+		Width:          width,
+		Height:         height,
+		Access:         ReadOrWrite,
+		Format:         format.format,
+		Xtype:          format.xtype,
+		InternalFormat: int32(format.internal),
+		MinFilter:      gl.NEAREST,
+		MagFilter:      gl.NEAREST,
+		ImageUnit:      uint32(unit),
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("creating texture for image uniform %q: %w", name, err)
+	}
+	cp.images[name] = tex
+	return nil
+}
+
+// AddBuffer creates a shader storage buffer of size bytes for the shader storage
+// block name and binds it to the block's binding point:
+//
+//	layout(std430, binding = 1) buffer name { float data[]; };
+func (cp *ComputePipeline) AddBuffer(name string, size int) error {
+	if cp.has(name) {
+		return fmt.Errorf("compute pipeline already has %q", name)
+	} else if size <= 0 {
+		return errors.New("non-positive buffer size")
+	}
+	binding, err := cp.prog.storageBlockBinding(name)
+	if err != nil {
+		return err
+	}
+	ssbo, err := NewShaderStorageBuffer[byte](nil, ShaderStorageBufferConfig{
+		Usage:   ReadOrWrite,
+		Base:    binding,
+		MemSize: uint32(size),
+	})
+	if err != nil {
+		return fmt.Errorf("creating buffer for shader storage block %q: %w", name, err)
+	}
+	cp.buffers[name] = ssbo
+	return nil
+}
+
+// Texture returns the texture added with [ComputePipeline.AddImage] for the image uniform name.
+func (cp *ComputePipeline) Texture(name string) (Texture, bool) {
+	tex, ok := cp.images[name]
+	return tex, ok
+}
+
+// Buffer returns the buffer added with [ComputePipeline.AddBuffer] for the shader storage block name.
+func (cp *ComputePipeline) Buffer(name string) (ShaderStorageBuffer, bool) {
+	ssbo, ok := cp.buffers[name]
+	return ssbo, ok
+}
+
+// Run binds the pipeline's program and runs it over nx*ny*nz work items. The number
+// of work groups is calculated from the shader's local work group size.
+// Run waits for the compute shader's writes to be visible before returning.
+func (cp *ComputePipeline) Run(nx, ny, nz int) error {
+	cp.prog.Bind()
+	return cp.prog.RunComputeForItems(nx, ny, nz, 0, 0, 0)
+}
+
+// Delete deletes the pipeline's program, textures and buffers.
+func (cp *ComputePipeline) Delete() {
+	for name, tex := range cp.images {
+		tex.Delete()
+		delete(cp.images, name)
+	}
+	for name, ssbo := range cp.buffers {
+		ssbo.Delete()
+		delete(cp.buffers, name)
+	}
+	cp.prog.Delete()
+}
+
+func (cp *ComputePipeline) has(name string) bool {
+	_, isImage := cp.images[name]
+	_, isBuffer := cp.buffers[name]
+	return isImage || isBuffer
+}
+
+// SetComputeInput writes data to the image or buffer name of the pipeline.
+// Images must be written whole. Buffers are written starting at offset zero
+// and data must fit in the buffer.
+func SetComputeInput[T any](cp *ComputePipeline, name string, data []T) error {
+	if len(data) == 0 {
+		return errors.New("zero length or nil buffer")
+	}
+	if tex, ok := cp.images[name]; ok {
+		return SetImage2D(tex, TextureImgConfig{}, data)
+	}
+	ssbo, ok := cp.buffers[name]
+	if !ok {
+		return fmt.Errorf("compute pipeline has no image or buffer %q", name)
+	}
+	return ssbo.obj.subData(0, elemSize[T]()*len(data), unsafe.Pointer(&data[0]))
+}
+
+// GetComputeOutput reads the image or buffer name of the pipeline into dst.
+// Images must be read whole. Buffers are read starting at offset zero.
+func GetComputeOutput[T any](cp *ComputePipeline, name string, dst []T) error {
+	if tex, ok := cp.images[name]; ok {
+		return GetImage(dst, tex, TextureImgConfig{})
+	}
+	ssbo, ok := cp.buffers[name]
+	if !ok {
+		return fmt.Errorf("compute pipeline has no image or buffer %q", name)
+	}
+	return CopyFromShaderStorageBuffer(dst, ssbo)
+}
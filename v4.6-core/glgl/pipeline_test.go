@@ -0,0 +1,64 @@
+//go:build !tinygo && cgo
+
+package glgl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/soypat/glgl/v4.6-core/glgl"
+)
+
+func TestComputePipeline(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	ss, err := glgl.ParseCombined(strings.NewReader(`#shader compute
+#version 430
+layout(local_size_x = 4, local_size_y = 1, local_size_z = 1) in;
+layout(r32f, binding = 3) uniform image2D in_tex;
+layout(std430, binding = 1) buffer Output {
+	float data[];
+};
+void main() {
+	uint i = gl_GlobalInvocationID.x;
+	data[i] = 2.0 * imageLoad(in_tex, ivec2(i, 0)).r;
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp, err := glgl.NewComputePipeline(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cp.Delete()
+	const n = 6
+	if err = cp.AddImage("in_tex", n, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = cp.AddBuffer("Output", n*4); err != nil {
+		t.Fatal(err)
+	}
+	if err = cp.AddBuffer("Output", n*4); err == nil {
+		t.Error("expected error adding buffer twice")
+	}
+	if err = cp.AddImage("missing", n, 1); err == nil {
+		t.Error("expected error adding undeclared image")
+	}
+	input := []float32{1, 2, 3, 4, 5, 6}
+	if err = glgl.SetComputeInput(cp, "in_tex", input); err != nil {
+		t.Fatal(err)
+	}
+	if err = cp.Run(n, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	output := make([]float32, n)
+	if err = glgl.GetComputeOutput(cp, "Output", output); err != nil {
+		t.Fatal(err)
+	}
+	for i := range input {
+		if output[i] != 2*input[i] {
+			t.Errorf("output %d: want %v, got %v", i, 2*input[i], output[i])
+		}
+	}
+}
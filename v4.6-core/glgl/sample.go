@@ -0,0 +1,86 @@
+package glgl
+
+import "math"
+
+// Texture wrap modes accepted by [SampleBilinear]. Their values are equal to the
+// GL enums of the same name so SampleBilinear can be used without cgo.
+const (
+	wrapRepeat         = 0x2901 // gl.REPEAT
+	wrapClampToEdge    = 0x812F // gl.CLAMP_TO_EDGE
+	wrapClampToBorder  = 0x812D // gl.CLAMP_TO_BORDER
+	wrapMirroredRepeat = 0x8370 // gl.MIRRORED_REPEAT
+)
+
+// SampleBilinear samples 2D image data at normalized texture coordinates u, v
+// emulating GL_LINEAR filtering on the CPU. It is useful for verifying shader
+// results without a GPU. data is stored row major with channels values per texel
+// and must be of length width*height*channels.
+// wrap is the wrapping mode applied to both texture axes, one of gl.REPEAT,
+// gl.MIRRORED_REPEAT, gl.CLAMP_TO_EDGE or gl.CLAMP_TO_BORDER (with a zero border color).
+// If wrap is zero gl.REPEAT is used, same as [NewTextureFromImage].
+//
+// The returned slice contains the channels interpolated values.
+func SampleBilinear(data []float32, width, height, channels int, u, v float32, wrap int32) []float32 {
+	if width <= 0 || height <= 0 || channels <= 0 {
+		panic("non-positive image dimension")
+	} else if len(data) != width*height*channels {
+		panic("image data length mismatch with dimensions")
+	}
+	if wrap == 0 {
+		wrap = wrapRepeat
+	}
+	// Texel centers lie at half integer coordinates.
+	x := float64(u)*float64(width) - 0.5
+	y := float64(v)*float64(height) - 0.5
+	x0f, y0f := math.Floor(x), math.Floor(y)
+	ax, ay := float32(x-x0f), float32(y-y0f)
+	x0, y0 := int(x0f), int(y0f)
+
+	ix0, okx0 := wrapTexel(x0, width, wrap)
+	ix1, okx1 := wrapTexel(x0+1, width, wrap)
+	iy0, oky0 := wrapTexel(y0, height, wrap)
+	iy1, oky1 := wrapTexel(y0+1, height, wrap)
+	result := make([]float32, channels)
+	texel := func(i, j int, ok bool, c int) float32 {
+		if !ok {
+			return 0 // Border color.
+		}
+		return data[(j*width+i)*channels+c]
+	}
+	for c := range result {
+		t00 := texel(ix0, iy0, okx0 && oky0, c)
+		t10 := texel(ix1, iy0, okx1 && oky0, c)
+		t01 := texel(ix0, iy1, okx0 && oky1, c)
+		t11 := texel(ix1, iy1, okx1 && oky1, c)
+		result[c] = (1-ax)*(1-ay)*t00 + ax*(1-ay)*t10 + (1-ax)*ay*t01 + ax*ay*t11
+	}
+	return result
+}
+
+// wrapTexel maps texel index i into [0, size) according to the wrap mode.
+// ok is false if the texel lies on the border when using CLAMP_TO_BORDER.
+func wrapTexel(i, size int, wrap int32) (_ int, ok bool) {
+	switch wrap {
+	case wrapClampToEdge:
+		return min(max(i, 0), size-1), true
+	case wrapClampToBorder:
+		return i, i >= 0 && i < size
+	case wrapMirroredRepeat:
+		period := 2 * size
+		i %= period
+		if i < 0 {
+			i += period
+		}
+		if i >= size {
+			i = period - 1 - i
+		}
+		return i, true
+	case wrapRepeat:
+		i %= size
+		if i < 0 {
+			i += size
+		}
+		return i, true
+	}
+	panic("unsupported wrap mode")
+}
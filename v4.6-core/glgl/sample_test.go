@@ -0,0 +1,45 @@
+package glgl
+
+import (
+	"testing"
+
+	"github.com/soypat/glgl/math/ms1"
+)
+
+func TestSampleBilinear(t *testing.T) {
+	const tol = 1e-6
+	// 2x2 single channel image:
+	//  2 3
+	//  0 1
+	data := []float32{0, 1, 2, 3}
+	for _, test := range []struct {
+		u, v float32
+		wrap int32
+		want float32
+	}{
+		// Texel centers return texel values.
+		{u: 0.25, v: 0.25, wrap: wrapClampToEdge, want: 0},
+		{u: 0.75, v: 0.25, wrap: wrapClampToEdge, want: 1},
+		{u: 0.25, v: 0.75, wrap: wrapClampToEdge, want: 2},
+		{u: 0.75, v: 0.75, wrap: wrapClampToEdge, want: 3},
+		{u: 0.5, v: 0.5, wrap: wrapClampToEdge, want: 1.5},
+		// Edges.
+		{u: 0, v: 0.25, wrap: wrapClampToEdge, want: 0},
+		{u: 0, v: 0.25, wrap: wrapRepeat, want: 0.5},
+		{u: 0, v: 0.25, wrap: wrapMirroredRepeat, want: 0},
+		{u: 0, v: 0.25, wrap: wrapClampToBorder, want: 0},
+		{u: 1, v: 0.25, wrap: wrapClampToBorder, want: 0.5},
+		{u: 1.25, v: 0.25, wrap: 0, want: 0},
+	} {
+		got := SampleBilinear(data, 2, 2, 1, test.u, test.v, test.wrap)
+		if !ms1.EqualWithinAbs(got[0], test.want, tol) {
+			t.Errorf("sample at (%v,%v) wrap=%#x want %v, got %v", test.u, test.v, test.wrap, test.want, got[0])
+		}
+	}
+	// Multiple channels are interpolated independently.
+	rg := []float32{0, 10, 1, 20}
+	got := SampleBilinear(rg, 2, 1, 2, 0.5, 0.5, wrapClampToEdge)
+	if !ms1.EqualWithinAbs(got[0], 0.5, tol) || !ms1.EqualWithinAbs(got[1], 15, tol) {
+		t.Errorf("multichannel sample want [0.5 15], got %v", got)
+	}
+}
@@ -0,0 +1,184 @@
+// Package sdf generates GLSL compute programs that evaluate signed distance
+// functions (SDFs) described by a tree of [Shaderer] nodes.
+//
+// Package sdf performs no calls to the GL so generated programs can be
+// inspected and tested without a GL context.
+package sdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/soypat/glgl/math/ms3"
+)
+
+// Shaderer is a node of an SDF tree which can write its own GLSL function.
+type Shaderer interface {
+	// Bounds returns the axis aligned bounding box of the SDF.
+	Bounds() (min, max ms3.Vec)
+	// AppendShader appends the shader's function name to glsl.Name and
+	// the function body to glsl.Body. The function must take a single
+	// `vec3 p` argument and return the float distance to the surface.
+	// Nodes with children should reference them via [Shader.AppendChildName].
+	AppendShader(glsl *Shader) error
+	// ForEachChild calls fn for each of the direct children of the node.
+	ForEachChild(flags int, fn func(flags int, s Shaderer) error) error
+}
+
+// Shader holds the GLSL function name and body of a [Shaderer].
+type Shader struct {
+	Name []byte
+	Body []byte
+}
+
+// scratchPool holds Shader scratch buffers so that generating large SDF
+// trees reuses buffers instead of allocating new ones for every node.
+var scratchPool = sync.Pool{
+	New: func() any { return new(Shader) },
+}
+
+// getScratch returns an empty scratch Shader from the pool.
+func getScratch() *Shader {
+	scratch := scratchPool.Get().(*Shader)
+	scratch.Name = scratch.Name[:0]
+	scratch.Body = scratch.Body[:0]
+	return scratch
+}
+
+// putScratch returns scratch to the pool. scratch must not be used after the call.
+func putScratch(scratch *Shader) {
+	scratchPool.Put(scratch)
+}
+
+// AppendChildName appends the function name of child to glsl.Name without
+// modifying glsl.Body. It is meant to be used by composite nodes in their
+// AppendShader method to reference the functions of their children,
+// which are written separately by [WriteProgram].
+func (glsl *Shader) AppendChildName(child Shaderer) error {
+	scratch := getScratch()
+	defer putScratch(scratch)
+	err := child.AppendShader(scratch)
+	if err != nil {
+		return err
+	}
+	glsl.Name = append(glsl.Name, scratch.Name...)
+	return nil
+}
+
+// WriteProgram writes a combined compute shader program to w that evaluates
+// root at every position of the rgba32f image bound to image unit 0 and
+// stores the distance in the r32f image bound to image unit 1.
+// The output may be parsed with glgl.ParseCombined.
+//
+// Each distinct function of the SDF tree is written once, so reusing a node in
+// several places of the tree does not result in duplicate GLSL definitions.
+func WriteProgram(w io.Writer, root Shaderer) error {
+	if root == nil {
+		return fmt.Errorf("nil root SDF")
+	}
+	scratch := getScratch()
+	defer putScratch(scratch)
+	err := root.AppendShader(scratch)
+	if err != nil {
+		return err
+	}
+	topname := string(scratch.Name)
+
+	// Breadth first traversal of the tree. Functions are written
+	// from the leaves to the root so definitions precede their use.
+	children := []Shaderer{root}
+	nextChild := 0
+	for len(children[nextChild:]) > 0 {
+		prev := len(children)
+		for _, obj := range children[nextChild:] {
+			err = obj.ForEachChild(0, func(flags int, s Shaderer) error {
+				if s == nil {
+					return fmt.Errorf("nil child of %T", obj)
+				}
+				children = append(children, s)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		nextChild = prev
+	}
+	const programHeader = "#shader compute\n#version 430\n"
+	_, err = io.WriteString(w, programHeader)
+	if err != nil {
+		return err
+	}
+
+	written := make(map[string]struct{}, len(children))
+	for i := len(children) - 1; i >= 0; i-- {
+		err = appendFunc(scratch, children[i])
+		if err != nil {
+			return err
+		}
+		if _, ok := written[string(scratch.Name)]; ok {
+			continue // Function already defined.
+		}
+		written[string(scratch.Name)] = struct{}{}
+		err = writeFunc(w, scratch)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, `
+layout(local_size_x = 1, local_size_y = 1, local_size_z = 1) in;
+layout(rgba32f, binding = 0) uniform image2D in_tex;
+// The binding argument refers to the textures Unit.
+layout(r32f, binding = 1) uniform image2D out_tex;
+
+void main() {
+	// get position to read/write data from.
+	ivec2 pos = ivec2( gl_GlobalInvocationID.xy );
+	// Get SDF position value.
+	vec3 p = imageLoad( in_tex, pos ).rgb;
+	float distance = %s(p);
+	// store new value in image
+	imageStore( out_tex, pos, vec4( distance, 0.0, 0.0, 0.0 ) );
+}
+`, topname)
+	return err
+}
+
+// appendFunc resets scratch and stores the function name and body of s in it.
+func appendFunc(scratch *Shader, s Shaderer) error {
+	scratch.Name = scratch.Name[:0]
+	scratch.Body = scratch.Body[:0]
+	err := s.AppendShader(scratch)
+	if err != nil {
+		return err
+	}
+	if len(scratch.Name) == 0 {
+		return fmt.Errorf("%T appended empty function name", s)
+	} else if bytes.IndexByte(scratch.Name, ' ') >= 0 {
+		return fmt.Errorf("%T appended invalid function name %q", s, scratch.Name)
+	}
+	return nil
+}
+
+func writeFunc(w io.Writer, fn *Shader) error {
+	_, err := io.WriteString(w, "float ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(fn.Name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "(vec3 p) {\n")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(fn.Body)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n}\n\n")
+	return err
+}
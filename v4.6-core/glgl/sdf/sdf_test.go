@@ -0,0 +1,147 @@
+package sdf_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/soypat/glgl/math/ms3"
+	"github.com/soypat/glgl/v4.6-core/glgl/sdf"
+)
+
+func TestWriteProgram(t *testing.T) {
+	s1 := &sphere{r: 1}
+	s2 := &sphere{r: 2}
+	root := &union{s1: s1, s2: &union{s1: s2, s2: s1}}
+	var buf bytes.Buffer
+	err := sdf.WriteProgram(&buf, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog := buf.String()
+	if !strings.HasPrefix(prog, "#shader compute\n") {
+		t.Fatal("missing compute pragma")
+	}
+	wantDefs := map[string]int{
+		"float sphere1(vec3 p)":                           1,
+		"float sphere2(vec3 p)":                           1,
+		"float union_sphere2sphere1(vec3 p)":              1,
+		"float union_sphere1union_sphere2sphere1(vec3 p)": 1,
+	}
+	for def, want := range wantDefs {
+		got := strings.Count(prog, def)
+		if got != want {
+			t.Errorf("want %d definitions of %q, got %d", want, def, got)
+		}
+	}
+	// Definitions must precede their use.
+	if strings.Index(prog, "float sphere1(") > strings.Index(prog, "float union_sphere2sphere1(") {
+		t.Error("child definition written after parent")
+	}
+	if !strings.Contains(prog, "float distance = union_sphere1union_sphere2sphere1(p);") {
+		t.Error("main does not call root function")
+	}
+}
+
+func TestWriteProgramNil(t *testing.T) {
+	var buf bytes.Buffer
+	err := sdf.WriteProgram(&buf, nil)
+	if err == nil {
+		t.Error("expected error for nil root")
+	}
+	err = sdf.WriteProgram(&buf, &union{s1: &sphere{r: 1}})
+	if err == nil {
+		t.Error("expected error for nil child")
+	}
+}
+
+func TestAppendChildName(t *testing.T) {
+	glsl := sdf.Shader{Name: []byte("op_"), Body: []byte("return 0.0;")}
+	err := glsl.AppendChildName(&union{s1: &sphere{r: 1}, s2: &sphere{r: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(glsl.Name) != "op_union_sphere1sphere2" {
+		t.Errorf("unexpected name %q", glsl.Name)
+	}
+	if string(glsl.Body) != "return 0.0;" {
+		t.Errorf("body modified: %q", glsl.Body)
+	}
+}
+
+func BenchmarkWriteProgramDeepUnion(b *testing.B) {
+	const depth = 64
+	var root sdf.Shaderer = &sphere{r: 1}
+	for i := 0; i < depth; i++ {
+		root = &union{s1: &sphere{r: float32(i + 2)}, s2: root}
+	}
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		err := sdf.WriteProgram(&buf, root)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type sphere struct{ r float32 }
+
+func (s *sphere) Bounds() (min, max ms3.Vec) {
+	return ms3.Vec{X: -s.r, Y: -s.r, Z: -s.r}, ms3.Vec{X: s.r, Y: s.r, Z: s.r}
+}
+
+func (s *sphere) ForEachChild(flags int, fn func(flags int, s sdf.Shaderer) error) error { return nil }
+
+func (s *sphere) AppendShader(glsl *sdf.Shader) error {
+	glsl.Name = append(glsl.Name, "sphere"...)
+	glsl.Name = strconv.AppendFloat(glsl.Name, float64(s.r), 'g', -1, 32)
+	glsl.Body = append(glsl.Body, "return length(p)-"...)
+	glsl.Body = strconv.AppendFloat(glsl.Body, float64(s.r), 'f', -1, 32)
+	glsl.Body = append(glsl.Body, ';')
+	return nil
+}
+
+type union struct{ s1, s2 sdf.Shaderer }
+
+func (u *union) Bounds() (min, max ms3.Vec) {
+	min1, max1 := u.s1.Bounds()
+	min2, max2 := u.s2.Bounds()
+	return ms3.MinElem(min1, min2), ms3.MaxElem(max1, max2)
+}
+
+func (u *union) ForEachChild(flags int, fn func(flags int, s sdf.Shaderer) error) error {
+	err := fn(flags, u.s1)
+	if err != nil {
+		return err
+	}
+	return fn(flags, u.s2)
+}
+
+func (u *union) AppendShader(glsl *sdf.Shader) error {
+	if u.s1 == nil || u.s2 == nil {
+		// Only name is needed to detect nil children in WriteProgram.
+		glsl.Name = append(glsl.Name, "union_"...)
+		return nil
+	}
+	glsl.Name = append(glsl.Name, "union_"...)
+	id1Start := len(glsl.Name)
+	err := glsl.AppendChildName(u.s1)
+	if err != nil {
+		return err
+	}
+	id2Start := len(glsl.Name)
+	err = glsl.AppendChildName(u.s2)
+	if err != nil {
+		return err
+	}
+	glsl.Body = append(glsl.Body, "return min("...)
+	glsl.Body = append(glsl.Body, glsl.Name[id1Start:id2Start]...)
+	glsl.Body = append(glsl.Body, "(p),"...)
+	glsl.Body = append(glsl.Body, glsl.Name[id2Start:]...)
+	glsl.Body = append(glsl.Body, "(p));"...)
+	return nil
+}
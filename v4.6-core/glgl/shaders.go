@@ -5,20 +5,112 @@ package glgl
 import (
 	"errors"
 	"fmt"
+	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/soypat/glgl/math/ms2"
+	"github.com/soypat/glgl/math/ms3"
 )
 
 // RunCompute runs a the program's compute shader with defined work sizes and waits for it to finish.
+// The work sizes are the number of work groups dispatched in each dimension. An error naming
+// the exceeded dimension is returned if a work size exceeds [MaxComputeWorkGroupCount].
+// The limits are queried once per context and cached.
 func (p Program) RunCompute(workSizeX, workSizeY, workSizeZ int) error {
+	limits := maxComputeWorkGroupCountCached()
+	maxX, maxY, maxZ := limits[0], limits[1], limits[2]
+	for _, dim := range [...]struct {
+		name      string
+		size, max int
+	}{
+		{name: "x", size: workSizeX, max: maxX},
+		{name: "y", size: workSizeY, max: maxY},
+		{name: "z", size: workSizeZ, max: maxZ},
+	} {
+		if dim.size < 0 {
+			return fmt.Errorf("negative compute work group count %s=%d", dim.name, dim.size)
+		} else if dim.max > 0 && dim.size > dim.max {
+			return fmt.Errorf("compute work group count %s=%d exceeds GL limit MAX_COMPUTE_WORK_GROUP_COUNT[%s]=%d", dim.name, dim.size, dim.name, dim.max)
+		}
+	}
 	gl.DispatchCompute(uint32(workSizeX), uint32(workSizeY), uint32(workSizeZ))
 	err := Err()
 	if err != nil {
 		return err
 	}
 	// Wait for compute to finish.
-	gl.MemoryBarrier(gl.ALL_BARRIER_BITS)
+	return MemoryBarrier(BarrierAll)
+}
+
+// ComputeWorkGroupSize returns the local work group size declared in the
+// program's compute shader via the layout qualifier:
+//
+//	layout(local_size_x = X, local_size_y = Y, local_size_z = Z) in;
+//
+// The program must have been successfully linked with a compute shader.
+func (p Program) ComputeWorkGroupSize() (x, y, z int) {
+	var size [3]int32
+	var pin runtime.Pinner
+	pin.Pin(&size)
+	defer pin.Unpin()
+	gl.GetProgramiv(p.rid, gl.COMPUTE_WORK_GROUP_SIZE, &size[0])
+	return int(size[0]), int(size[1]), int(size[2])
+}
+
+// RunComputeForItems runs the program's compute shader over nx*ny*nz work items
+// given the shader's declared local work group size localX, localY, localZ.
+// The number of work groups dispatched in each dimension is calculated with [DispatchSizeFor].
+// If the local work group size is all zeros then it is read from the program
+// with [Program.ComputeWorkGroupSize].
+func (p Program) RunComputeForItems(nx, ny, nz, localX, localY, localZ int) error {
+	if localX == 0 && localY == 0 && localZ == 0 {
+		localX, localY, localZ = p.ComputeWorkGroupSize()
+		if err := Err(); err != nil {
+			return fmt.Errorf("reading compute work group size: %w", err)
+		}
+	}
+	if localX <= 0 || localY <= 0 || localZ <= 0 {
+		return errors.New("non-positive compute local work group size")
+	} else if nx <= 0 || ny <= 0 || nz <= 0 {
+		return errors.New("non-positive number of compute work items")
+	}
+	return p.RunCompute(DispatchSizeFor(nx, localX), DispatchSizeFor(ny, localY), DispatchSizeFor(nz, localZ))
+}
+
+// Memory barrier bits. See [BarrierMask] documentation for more information.
+const (
+	BarrierVertexAttribArray  BarrierMask = gl.VERTEX_ATTRIB_ARRAY_BARRIER_BIT
+	BarrierElementArray       BarrierMask = gl.ELEMENT_ARRAY_BARRIER_BIT
+	BarrierUniform            BarrierMask = gl.UNIFORM_BARRIER_BIT
+	BarrierTextureFetch       BarrierMask = gl.TEXTURE_FETCH_BARRIER_BIT
+	BarrierShaderImageAccess  BarrierMask = gl.SHADER_IMAGE_ACCESS_BARRIER_BIT
+	BarrierCommand            BarrierMask = gl.COMMAND_BARRIER_BIT
+	BarrierPixelBuffer        BarrierMask = gl.PIXEL_BUFFER_BARRIER_BIT
+	BarrierTextureUpdate      BarrierMask = gl.TEXTURE_UPDATE_BARRIER_BIT
+	BarrierBufferUpdate       BarrierMask = gl.BUFFER_UPDATE_BARRIER_BIT
+	BarrierFramebuffer        BarrierMask = gl.FRAMEBUFFER_BARRIER_BIT
+	BarrierTransformFeedback  BarrierMask = gl.TRANSFORM_FEEDBACK_BARRIER_BIT
+	BarrierAtomicCounter      BarrierMask = gl.ATOMIC_COUNTER_BARRIER_BIT
+	BarrierShaderStorage      BarrierMask = gl.SHADER_STORAGE_BARRIER_BIT
+	BarrierClientMappedBuffer BarrierMask = gl.CLIENT_MAPPED_BUFFER_BARRIER_BIT
+	BarrierQueryBuffer        BarrierMask = gl.QUERY_BUFFER_BARRIER_BIT
+	BarrierAll                BarrierMask = gl.ALL_BARRIER_BITS
+)
+
+// MemoryBarrier defines a barrier ordering the memory transactions issued prior
+// to the command relative to those issued after the barrier via glMemoryBarrier.
+// Use it between dispatches that write and read the same resources:
+//
+//	// First dispatch writes to image2D, second dispatch reads it.
+//	prog1.Bind()
+//	gl.DispatchCompute(nx, ny, 1)
+//	glgl.MemoryBarrier(glgl.BarrierShaderImageAccess)
+//	prog2.Bind()
+//	gl.DispatchCompute(nx, ny, 1)
+func MemoryBarrier(mask BarrierMask) error {
+	gl.MemoryBarrier(uint32(mask))
 	return Err()
 }
 
@@ -47,6 +139,156 @@ func (p Program) Delete() {
 	gl.DeleteProgram(p.rid)
 }
 
+// Validate checks whether the program can execute given the current GL state
+// via glValidateProgram and returns the validation log as an error on failure.
+// Validation is most meaningful right before a draw or dispatch call with all
+// relevant state (textures, buffers, vertex arrays) bound.
+func (p Program) Validate() error {
+	gl.ValidateProgram(p.rid)
+	log := ivLog(p.rid, gl.VALIDATE_STATUS, gl.GetProgramiv, gl.GetProgramInfoLog)
+	if log != "" {
+		return fmt.Errorf("validation failed: %s", log)
+	}
+	// An invalid program is reported even if the GL left its info log empty.
+	var status int32
+	gl.GetProgramiv(p.rid, gl.VALIDATE_STATUS, &status)
+	if status == gl.FALSE {
+		return errors.New("validation failed with empty info log")
+	}
+	return Err()
+}
+
+// ValidateImageFormats checks that the image units read by the active image uniforms
+// of p, as declared in ss, have a texture bound with the format given by the uniform's
+// format layout qualifier. A descriptive error is returned for the first uniform with
+// no texture bound or a mismatched format. Call it before a dispatch or draw since
+// mismatched formats are not reported by the GL and yield silently wrong results.
+// Image uniforms without a format qualifier are not checked.
+func (p Program) ValidateImageFormats(ss ShaderSource) error {
+	for _, u := range ss.ImageUniforms() {
+		if u.Format == "" {
+			continue
+		}
+		want, ok := imageFormatQualifiers[u.Format]
+		if !ok {
+			return fmt.Errorf("image uniform %q has unknown format qualifier %q", u.Name, u.Format)
+		}
+		unit, ok := p.imageUnit(u.Name)
+		if !ok {
+			continue // Inactive uniform optimized away by the GL.
+		}
+		var texture, format int32
+		gl.GetIntegeri_v(gl.IMAGE_BINDING_NAME, uint32(unit), &texture)
+		gl.GetIntegeri_v(gl.IMAGE_BINDING_FORMAT, uint32(unit), &format)
+		if err := Err(); err != nil {
+			return err
+		}
+		if texture == 0 {
+			return fmt.Errorf("image uniform %q reads image unit %d which has no texture bound", u.Name, unit)
+		} else if uint32(format) != want.internal {
+			return fmt.Errorf("image uniform %q declared with format %s but image unit %d is bound with format %s",
+				u.Name, u.Format, unit, imageFormatName(uint32(format)))
+		}
+	}
+	return nil
+}
+
+// imageUnit returns the image unit read by the image uniform name. ok is false
+// if the uniform is not an active uniform of p.
+func (p Program) imageUnit(name string) (unit int32, ok bool) {
+	loc := gl.GetUniformLocation(p.rid, gl.Str(name+"\x00"))
+	if loc < 0 {
+		return -1, false
+	}
+	gl.GetUniformiv(p.rid, loc, &unit)
+	return unit, true
+}
+
+// storageBlockBinding returns the binding point of the shader storage block name.
+func (p Program) storageBlockBinding(name string) (uint32, error) {
+	idx := gl.GetProgramResourceIndex(p.rid, gl.SHADER_STORAGE_BLOCK, gl.Str(name+"\x00"))
+	if idx == gl.INVALID_INDEX {
+		return 0, fmt.Errorf("shader storage block %q not found in program", name)
+	}
+	prop := uint32(gl.BUFFER_BINDING)
+	var binding int32
+	gl.GetProgramResourceiv(p.rid, gl.SHADER_STORAGE_BLOCK, idx, 1, &prop, 1, nil, &binding)
+	return uint32(binding), Err()
+}
+
+// UniformBlockBinding assigns the uniform block name to the uniform buffer binding point,
+// i.e. the Base of a [UniformBuffer]. name must be null terminated.
+func (p Program) UniformBlockBinding(name string, binding uint32) error {
+	if !strings.HasSuffix(name, "\x00") {
+		return ErrStringNotNullTerminated
+	}
+	idx := gl.GetUniformBlockIndex(p.rid, gl.Str(name))
+	if idx == gl.INVALID_INDEX {
+		return fmt.Errorf("uniform block %q not found in program", strings.TrimSuffix(name, "\x00"))
+	}
+	gl.UniformBlockBinding(p.rid, idx, binding)
+	return Err()
+}
+
+// imageFormat is the internal format and matching pixel transfer format and type
+// of a GLSL image format layout qualifier.
+type imageFormat struct {
+	internal, format, xtype uint32
+}
+
+// imageFormatQualifiers maps GLSL image format layout qualifiers to their formats.
+var imageFormatQualifiers = map[string]imageFormat{
+	"rgba32f":        {gl.RGBA32F, gl.RGBA, gl.FLOAT},
+	"rgba16f":        {gl.RGBA16F, gl.RGBA, gl.HALF_FLOAT},
+	"rg32f":          {gl.RG32F, gl.RG, gl.FLOAT},
+	"rg16f":          {gl.RG16F, gl.RG, gl.HALF_FLOAT},
+	"r11f_g11f_b10f": {gl.R11F_G11F_B10F, gl.RGB, gl.UNSIGNED_INT_10F_11F_11F_REV},
+	"r32f":           {gl.R32F, gl.RED, gl.FLOAT},
+	"r16f":           {gl.R16F, gl.RED, gl.HALF_FLOAT},
+	"rgba16":         {gl.RGBA16, gl.RGBA, gl.UNSIGNED_SHORT},
+	"rgb10_a2":       {gl.RGB10_A2, gl.RGBA, gl.UNSIGNED_INT_2_10_10_10_REV},
+	"rgba8":          {gl.RGBA8, gl.RGBA, gl.UNSIGNED_BYTE},
+	"rg16":           {gl.RG16, gl.RG, gl.UNSIGNED_SHORT},
+	"rg8":            {gl.RG8, gl.RG, gl.UNSIGNED_BYTE},
+	"r16":            {gl.R16, gl.RED, gl.UNSIGNED_SHORT},
+	"r8":             {gl.R8, gl.RED, gl.UNSIGNED_BYTE},
+	"rgba16_snorm":   {gl.RGBA16_SNORM, gl.RGBA, gl.SHORT},
+	"rgba8_snorm":    {gl.RGBA8_SNORM, gl.RGBA, gl.BYTE},
+	"rg16_snorm":     {gl.RG16_SNORM, gl.RG, gl.SHORT},
+	"rg8_snorm":      {gl.RG8_SNORM, gl.RG, gl.BYTE},
+	"r16_snorm":      {gl.R16_SNORM, gl.RED, gl.SHORT},
+	"r8_snorm":       {gl.R8_SNORM, gl.RED, gl.BYTE},
+	"rgba32i":        {gl.RGBA32I, gl.RGBA_INTEGER, gl.INT},
+	"rgba16i":        {gl.RGBA16I, gl.RGBA_INTEGER, gl.SHORT},
+	"rgba8i":         {gl.RGBA8I, gl.RGBA_INTEGER, gl.BYTE},
+	"rg32i":          {gl.RG32I, gl.RG_INTEGER, gl.INT},
+	"rg16i":          {gl.RG16I, gl.RG_INTEGER, gl.SHORT},
+	"rg8i":           {gl.RG8I, gl.RG_INTEGER, gl.BYTE},
+	"r32i":           {gl.R32I, gl.RED_INTEGER, gl.INT},
+	"r16i":           {gl.R16I, gl.RED_INTEGER, gl.SHORT},
+	"r8i":            {gl.R8I, gl.RED_INTEGER, gl.BYTE},
+	"rgba32ui":       {gl.RGBA32UI, gl.RGBA_INTEGER, gl.UNSIGNED_INT},
+	"rgba16ui":       {gl.RGBA16UI, gl.RGBA_INTEGER, gl.UNSIGNED_SHORT},
+	"rgb10_a2ui":     {gl.RGB10_A2UI, gl.RGBA_INTEGER, gl.UNSIGNED_INT_2_10_10_10_REV},
+	"rgba8ui":        {gl.RGBA8UI, gl.RGBA_INTEGER, gl.UNSIGNED_BYTE},
+	"rg32ui":         {gl.RG32UI, gl.RG_INTEGER, gl.UNSIGNED_INT},
+	"rg16ui":         {gl.RG16UI, gl.RG_INTEGER, gl.UNSIGNED_SHORT},
+	"rg8ui":          {gl.RG8UI, gl.RG_INTEGER, gl.UNSIGNED_BYTE},
+	"r32ui":          {gl.R32UI, gl.RED_INTEGER, gl.UNSIGNED_INT},
+	"r16ui":          {gl.R16UI, gl.RED_INTEGER, gl.UNSIGNED_SHORT},
+	"r8ui":           {gl.R8UI, gl.RED_INTEGER, gl.UNSIGNED_BYTE},
+}
+
+// imageFormatName returns the GLSL format qualifier of an internal format or its hexadecimal value if unknown.
+func imageFormatName(internalFormat uint32) string {
+	for name, format := range imageFormatQualifiers {
+		if format.internal == internalFormat {
+			return name
+		}
+	}
+	return fmt.Sprintf("%#x", internalFormat)
+}
+
 func (p Program) AttribLocation(name string) (uint32, error) {
 	if !strings.HasSuffix(name, "\x00") {
 		return 0, ErrStringNotNullTerminated
@@ -58,6 +300,37 @@ func (p Program) AttribLocation(name string) (uint32, error) {
 	return 0, nil
 }
 
+// ActiveAttributes returns the active vertex attributes of the program sorted
+// by location. Built-in attributes such as gl_VertexID are not included.
+// Attributes unused by the shader may be optimized away by the GL and will not be present.
+func (p Program) ActiveAttributes() ([]ActiveAttribute, error) {
+	var n, maxLen int32
+	gl.GetProgramiv(p.rid, gl.ACTIVE_ATTRIBUTES, &n)
+	gl.GetProgramiv(p.rid, gl.ACTIVE_ATTRIBUTE_MAX_LENGTH, &maxLen)
+	if err := Err(); err != nil {
+		return nil, err
+	}
+	name := make([]uint8, maxLen+1)
+	attrs := make([]ActiveAttribute, 0, n)
+	for i := uint32(0); i < uint32(n); i++ {
+		var length, size int32
+		var xtype uint32
+		gl.GetActiveAttrib(p.rid, i, int32(len(name)), &length, &size, &xtype, &name[0])
+		loc := gl.GetAttribLocation(p.rid, &name[0])
+		if loc < 0 {
+			continue // Built-in attribute.
+		}
+		attrs = append(attrs, ActiveAttribute{
+			Name:     string(name[:length]),
+			Location: uint32(loc),
+			GLSLType: xtype,
+			Size:     int(size),
+		})
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Location < attrs[j].Location })
+	return attrs, Err()
+}
+
 func (p Program) UniformLocation(name string) (int32, error) {
 	if !strings.HasSuffix(name, "\x00") {
 		return -2, ErrStringNotNullTerminated
@@ -117,6 +390,168 @@ func (p Program) SetUniformui(loc int32, ints ...uint32) error {
 	return Err()
 }
 
+// SetUniformfv sets a GLSL float or vector array uniform at loc, i.e: `uniform vec4 colors[8];`.
+// components is the number of floats per array element, 1 through 4, and
+// the array length is inferred from len(data)/components.
+func (p Program) SetUniformfv(loc int32, components int, data []float32) error {
+	if len(data) == 0 {
+		return errors.New("empty uniform array")
+	} else if components < 1 || components > 4 {
+		return errors.New("uniform array components must be 1, 2, 3 or 4")
+	} else if len(data)%components != 0 {
+		return errors.New("uniform array length not a multiple of components")
+	}
+	count := int32(len(data) / components)
+	switch components {
+	case 1:
+		gl.Uniform1fv(loc, count, &data[0])
+	case 2:
+		gl.Uniform2fv(loc, count, &data[0])
+	case 3:
+		gl.Uniform3fv(loc, count, &data[0])
+	case 4:
+		gl.Uniform4fv(loc, count, &data[0])
+	}
+	return Err()
+}
+
+// SetUniform1fv sets a GLSL float array uniform at loc, i.e: `uniform float weights[8];`.
+func (p Program) SetUniform1fv(loc int32, values []float32) error {
+	if len(values) == 0 {
+		return errors.New("empty uniform array")
+	}
+	gl.Uniform1fv(loc, int32(len(values)), &values[0])
+	return Err()
+}
+
+// SetUniform2fv sets a GLSL vec2 array uniform at loc.
+func (p Program) SetUniform2fv(loc int32, vecs []ms2.Vec) error {
+	if len(vecs) == 0 {
+		return errors.New("empty uniform array")
+	}
+	// ms2.Vec has no padding so it can be passed to the GL as is.
+	gl.Uniform2fv(loc, int32(len(vecs)), &vecs[0].X)
+	return Err()
+}
+
+// SetUniform3fv sets a GLSL vec3 array uniform at loc, i.e: `uniform vec3 lights[4];`.
+func (p Program) SetUniform3fv(loc int32, vecs []ms3.Vec) error {
+	if len(vecs) == 0 {
+		return errors.New("empty uniform array")
+	}
+	// ms3.Vec is padded to 16 bytes so it must be tightly packed before passing it to the GL.
+	floats := ms3.FlattenVecs(make([]float32, 0, 3*len(vecs)), vecs)
+	gl.Uniform3fv(loc, int32(len(vecs)), &floats[0])
+	return Err()
+}
+
+// SetUniform1iv sets a GLSL int array uniform at loc.
+func (p Program) SetUniform1iv(loc int32, values []int32) error {
+	if len(values) == 0 {
+		return errors.New("empty uniform array")
+	}
+	gl.Uniform1iv(loc, int32(len(values)), &values[0])
+	return Err()
+}
+
+// SetUniform1uiv sets a GLSL uint array uniform at loc.
+func (p Program) SetUniform1uiv(loc int32, values []uint32) error {
+	if len(values) == 0 {
+		return errors.New("empty uniform array")
+	}
+	gl.Uniform1uiv(loc, int32(len(values)), &values[0])
+	return Err()
+}
+
+// SetUniformVec2 sets a GLSL vec2 uniform at loc.
+func (p Program) SetUniformVec2(loc int32, v ms2.Vec) error {
+	return p.SetUniformf(loc, v.X, v.Y)
+}
+
+// SetUniformVec3 sets a GLSL vec3 uniform at loc.
+func (p Program) SetUniformVec3(loc int32, v ms3.Vec) error {
+	return p.SetUniformf(loc, v.X, v.Y, v.Z)
+}
+
+// SetUniformNameVec2 sets the GLSL vec2 uniform with the null terminated identifier name.
+func (p Program) SetUniformNameVec2(name string, v ms2.Vec) error {
+	loc, err := p.UniformLocation(name)
+	if err != nil {
+		return err
+	}
+	return p.SetUniformVec2(loc, v)
+}
+
+// SetUniformNameVec3 sets the GLSL vec3 uniform with the null terminated identifier name.
+func (p Program) SetUniformNameVec3(name string, v ms3.Vec) error {
+	loc, err := p.UniformLocation(name)
+	if err != nil {
+		return err
+	}
+	return p.SetUniformVec3(loc, v)
+}
+
+// SetUniformMat4 sets a GLSL mat4 uniform at loc. m is in row major order, same as
+// returned by [ms3.Mat4.Array], and is transposed by the GL to its column major layout.
+func (p Program) SetUniformMat4(loc int32, m [16]float32) error {
+	gl.UniformMatrix4fv(loc, 1, true, &m[0])
+	return Err()
+}
+
+// SetUniformMat3 sets a GLSL mat3 uniform at loc. m is in row major order, same as
+// returned by [ms3.Mat3.Array], and is transposed by the GL to its column major layout.
+func (p Program) SetUniformMat3(loc int32, m [9]float32) error {
+	gl.UniformMatrix3fv(loc, 1, true, &m[0])
+	return Err()
+}
+
+// SetUniformNameMat4 sets the GLSL mat4 uniform with the null terminated identifier name.
+// See [Program.SetUniformMat4].
+func (p Program) SetUniformNameMat4(name string, m [16]float32) error {
+	loc, err := p.UniformLocation(name)
+	if err != nil {
+		return err
+	}
+	return p.SetUniformMat4(loc, m)
+}
+
+// SetUniformNameMat3 sets the GLSL mat3 uniform with the null terminated identifier name.
+// See [Program.SetUniformMat3].
+func (p Program) SetUniformNameMat3(name string, m [9]float32) error {
+	loc, err := p.UniformLocation(name)
+	if err != nil {
+		return err
+	}
+	return p.SetUniformMat3(loc, m)
+}
+
+// SetUniformBool sets a GLSL bool uniform at loc. Booleans are set as integers 0 or 1.
+func (p Program) SetUniformBool(loc int32, b bool) error {
+	return p.SetUniformi(loc, int32(b2i(b)))
+}
+
+// SetUniformb sets a GLSL bool, bvec2, bvec3 or bvec4 uniform at loc depending
+// on the number of bools passed in. Booleans are set as integers 0 or 1.
+func (p Program) SetUniformb(loc int32, bools ...bool) error {
+	var ints [4]int32
+	if len(bools) > len(ints) {
+		return errors.New("bad number of bools to SetUniformb")
+	}
+	for i, b := range bools {
+		ints[i] = int32(b2i(b))
+	}
+	return p.SetUniformi(loc, ints[:len(bools)]...)
+}
+
+// SetUniformName1b sets the GLSL bool uniform with the null terminated identifier name.
+func (p Program) SetUniformName1b(name string, b bool) error {
+	loc, err := p.UniformLocation(name)
+	if err != nil {
+		return err
+	}
+	return p.SetUniformBool(loc, b)
+}
+
 // CompileBasic compiles two OpenGL vertex and fragment shaders
 // and returns a program with the current OpenGL context.
 // It returns an error if compilation, linking or validation fails.
@@ -217,7 +652,7 @@ func compile(shaderType uint32, sourceCodes ...string) (uint32, error) {
 	// We now check the errors during compile, if there were any.
 	log := ivLog(id, gl.COMPILE_STATUS, gl.GetShaderiv, gl.GetShaderInfoLog)
 	if len(log) > 0 {
-		return 0, errors.New(log)
+		return 0, errors.New(annotateLog(log, sourceCodes...))
 	}
 	// if !gl.IsShader(id) {
 	// 	return 0, errors.New("shader ID unexpectedly does not correspond to shader")
@@ -239,9 +674,7 @@ func ivLog(id, plName uint32, getIV func(program uint32, pname uint32, params *i
 		var logLength int32
 		getIV(id, gl.INFO_LOG_LENGTH, &logLength)
 		if logLength == 0 {
-			// panic(fmt.Sprintf("unexpected false iv for plName=0x%#X with no log", plName))
-			fmt.Print("TODO: fix ivLog in shaders.go")
-			return ""
+			return "" // Status is false but the GL wrote no log, callers must check the status.
 		}
 		log := make([]byte, logLength)
 		getInfo(id, logLength, &logLength, &log[0])
@@ -0,0 +1,33 @@
+//go:build !tinygo && cgo
+
+package glgl
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+func TestImageFormatQualifiersValid(t *testing.T) {
+	// Every qualifier must yield a texture config accepted by ComputePipeline.AddImage.
+	for qualifier, format := range imageFormatQualifiers {
+		cfg := TextureImgConfig{
+			Format:         format.format,
+			Xtype:          format.xtype,
+			InternalFormat: int32(format.internal),
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("%s: %s", qualifier, err)
+		}
+		if _, ok := cfg.pixelSize(); !ok {
+			t.Errorf("%s: unknown pixel size", qualifier)
+		}
+	}
+}
+
+func TestWrapModeValues(t *testing.T) {
+	if wrapRepeat != gl.REPEAT || wrapClampToEdge != gl.CLAMP_TO_EDGE ||
+		wrapClampToBorder != gl.CLAMP_TO_BORDER || wrapMirroredRepeat != gl.MIRRORED_REPEAT {
+		t.Error("SampleBilinear wrap modes do not match GL enums")
+	}
+}
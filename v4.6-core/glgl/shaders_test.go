@@ -0,0 +1,201 @@
+//go:build !tinygo && cgo
+
+package glgl_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/soypat/glgl/math/ms3"
+	"github.com/soypat/glgl/v4.6-core/glgl"
+)
+
+func TestProgramSetUniformMat(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	ss, err := glgl.ParseCombined(strings.NewReader(`#shader compute
+#version 430
+layout(local_size_x = 1, local_size_y = 1, local_size_z = 1) in;
+layout(std430, binding = 0) buffer Output {
+	vec4 data[];
+};
+uniform mat4 u_m4;
+uniform mat3 u_m3;
+void main() {
+	data[0] = u_m4 * vec4(1.0, 2.0, 3.0, 1.0);
+	data[1] = vec4(u_m3 * vec3(1.0, 2.0, 3.0), 0.0);
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := glgl.CompileProgram(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer prog.Delete()
+	prog.Bind()
+	m4 := ms3.ComposeTRS(ms3.Vec{X: 1, Y: 2, Z: 3}, ms3.RotationQuat(0.5, ms3.Vec{Z: 1}), ms3.Vec{X: 2, Y: 1, Z: 1})
+	m3 := ms3.RotatingMat3(ms3.RotationQuat(1, ms3.Vec{X: 1}))
+	if err = prog.SetUniformNameMat4("u_m4\x00", m4.Array()); err != nil {
+		t.Fatal(err)
+	}
+	if err = prog.SetUniformNameMat3("u_m3\x00", m3.Array()); err != nil {
+		t.Fatal(err)
+	}
+	if err = prog.SetUniformMat4(-2, m4.Array()); err == nil {
+		t.Error("expected error setting matrix at invalid location")
+	}
+	ssbo, err := glgl.NewShaderStorageBuffer[float32](nil, glgl.ShaderStorageBufferConfig{Usage: glgl.ReadOrWrite, MemSize: 2 * 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ssbo.Delete()
+	if err = prog.RunCompute(1, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]float32, 8)
+	if err = glgl.CopyFromShaderStorageBuffer(got, ssbo); err != nil {
+		t.Fatal(err)
+	}
+	v := ms3.Vec{X: 1, Y: 2, Z: 3}
+	const tol = 1e-5
+	if want := m4.MulPosition(v); !want.Equal(ms3.Vec{X: got[0], Y: got[1], Z: got[2]}, tol) {
+		t.Errorf("mat4 uniform: want %v, got %v", want, got[:3])
+	}
+	if want := ms3.MulMatVec(m3, v); !want.Equal(ms3.Vec{X: got[4], Y: got[5], Z: got[6]}, tol) {
+		t.Errorf("mat3 uniform: want %v, got %v", want, got[4:7])
+	}
+}
+
+func TestProgramSetUniformfv(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	ss, err := glgl.ParseCombined(strings.NewReader(`#shader compute
+#version 430
+layout(local_size_x = 1, local_size_y = 1, local_size_z = 1) in;
+layout(std430, binding = 0) buffer Output {
+	vec4 data[];
+};
+uniform vec3 u_v3[2];
+uniform float u_f[3];
+void main() {
+	data[0] = vec4(u_v3[0] + u_v3[1], u_f[0] + u_f[1] + u_f[2]);
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := glgl.CompileProgram(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer prog.Delete()
+	prog.Bind()
+	locv3, err := prog.UniformLocation("u_v3\x00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	locf, err := prog.UniformLocation("u_f\x00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = prog.SetUniformfv(locv3, 3, []float32{1, 2, 3, 10, 20, 30}); err != nil {
+		t.Fatal(err)
+	}
+	if err = prog.SetUniformfv(locf, 1, []float32{1, 2, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if err = prog.SetUniformfv(locv3, 3, []float32{1, 2, 3, 4}); err == nil {
+		t.Error("expected error for data length not multiple of components")
+	}
+	if err = prog.SetUniformfv(locv3, 5, []float32{1, 2, 3, 4, 5}); err == nil {
+		t.Error("expected error for invalid number of components")
+	}
+	ssbo, err := glgl.NewShaderStorageBuffer[float32](nil, glgl.ShaderStorageBufferConfig{Usage: glgl.ReadOrWrite, MemSize: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ssbo.Delete()
+	if err = prog.RunCompute(1, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]float32, 4)
+	if err = glgl.CopyFromShaderStorageBuffer(got, ssbo); err != nil {
+		t.Fatal(err)
+	}
+	if want := []float32{11, 22, 33, 7}; !slices.Equal(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestProgramRunComputeLimits(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	ss, err := glgl.ParseCombined(strings.NewReader(`#shader compute
+#version 430
+layout(local_size_x = 1, local_size_y = 1, local_size_z = 1) in;
+void main() {}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := glgl.CompileProgram(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer prog.Delete()
+	prog.Bind()
+	_, maxY, _ := glgl.MaxComputeWorkGroupCount()
+	err = prog.RunCompute(1, maxY+1, 1)
+	if err == nil || !strings.Contains(err.Error(), "y=") {
+		t.Errorf("expected error naming exceeded y dimension, got %v", err)
+	}
+	if err = prog.RunCompute(1, -1, 1); err == nil {
+		t.Error("expected error for negative work group count")
+	}
+	if err = prog.RunCompute(1, 1, 1); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProgramValidate(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	ss, err := glgl.ParseCombined(strings.NewReader(`#shader compute
+#version 430
+layout(local_size_x = 1, local_size_y = 1, local_size_z = 1) in;
+layout(std430, binding = 0) buffer Output {
+	vec4 data[];
+};
+uniform sampler2D u_tex2d;
+uniform sampler3D u_tex3d;
+void main() {
+	data[0] = texture(u_tex2d, vec2(0.0)) + texture(u_tex3d, vec3(0.0));
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := glgl.CompileProgram(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer prog.Delete()
+	prog.Bind()
+	// Samplers of different types sharing the default texture unit 0 fail validation.
+	if err = prog.Validate(); err == nil {
+		t.Error("expected validation error for samplers of different type on same unit")
+	}
+	loc, err := prog.UniformLocation("u_tex3d\x00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = prog.SetUniformi(loc, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = prog.Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,280 @@
+//go:build !tinygo && cgo
+
+package glgl_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/soypat/glgl/v4.6-core/glgl"
+)
+
+func TestTextureResize(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	cfg := glgl.TextureImgConfig{
+		Type:           glgl.Texture2D,
+		Width:          2,
+		Height:         2,
+		Access:         glgl.ReadOrWrite,
+		Format:         gl.RED,
+		MinFilter:      gl.NEAREST,
+		MagFilter:      gl.NEAREST,
+		Xtype:          gl.FLOAT,
+		InternalFormat: gl.R32F,
+	}
+	tex, err := glgl.NewTextureFromImage(cfg, []float32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tex.Delete()
+	cp := tex
+	if err = tex.Resize(0, 3); err == nil {
+		t.Error("expected error resizing to zero width")
+	}
+	err = tex.Resize(3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var width, height int32
+	tex.Bind(0)
+	gl.GetTexLevelParameteriv(gl.TEXTURE_2D, 0, gl.TEXTURE_WIDTH, &width)
+	gl.GetTexLevelParameteriv(gl.TEXTURE_2D, 0, gl.TEXTURE_HEIGHT, &height)
+	if width != 3 || height != 5 {
+		t.Errorf("want resized texture 3x5, got %dx%d", width, height)
+	}
+	// Copies of the texture share its configuration so reads through them see the new size.
+	if got := cp.Config(); got.Width != 3 || got.Height != 5 {
+		t.Errorf("want texture copy config 3x5, got %dx%d", got.Width, got.Height)
+	}
+	if err = glgl.GetImage(make([]float32, 4), cp, glgl.TextureImgConfig{}); err == nil {
+		t.Error("expected error reading resized texture into buffer of old size")
+	}
+	if err = glgl.GetImage(make([]float32, 15), cp, glgl.TextureImgConfig{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTextureDelete(t *testing.T) {
+	var zero glgl.Texture
+	zero.Delete() // Zero value texture performs no GL calls on Delete.
+	term := initTestWindow(t)
+	defer term()
+	cfg := glgl.TextureImgConfig{
+		Type:      glgl.Texture2D,
+		Width:     1,
+		Height:    1,
+		Access:    glgl.ReadOrWrite,
+		Format:    gl.RED,
+		MinFilter: gl.NEAREST,
+		MagFilter: gl.NEAREST,
+		Xtype:     gl.FLOAT,
+	}
+	tex, err := glgl.NewTextureFromImage(cfg, []float32{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tex.Delete()
+	tex.Delete() // Double delete is a no-op.
+	if err = tex.Resize(2, 2); err == nil {
+		t.Error("expected error resizing deleted texture")
+	}
+}
+
+func TestTextureDepthStencil(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	for _, test := range []struct {
+		internal   int32
+		format     uint32
+		xtype      uint32
+		attachment uint32
+	}{
+		{internal: gl.DEPTH_COMPONENT32F, format: gl.DEPTH_COMPONENT, xtype: gl.FLOAT, attachment: gl.DEPTH_ATTACHMENT},
+		{internal: gl.DEPTH24_STENCIL8, format: gl.DEPTH_STENCIL, xtype: gl.UNSIGNED_INT_24_8, attachment: gl.DEPTH_STENCIL_ATTACHMENT},
+		{internal: gl.DEPTH32F_STENCIL8, format: gl.DEPTH_STENCIL, xtype: gl.FLOAT_32_UNSIGNED_INT_24_8_REV, attachment: gl.DEPTH_STENCIL_ATTACHMENT},
+		{internal: gl.STENCIL_INDEX8, format: gl.STENCIL_INDEX, xtype: gl.UNSIGNED_BYTE, attachment: gl.STENCIL_ATTACHMENT},
+	} {
+		// Depth and stencil formats are not valid image unit formats so they must not be bound as images.
+		tex, err := glgl.NewTextureFromImage[uint8](glgl.TextureImgConfig{
+			Type:           glgl.Texture2D,
+			Width:          4,
+			Height:         4,
+			Access:         glgl.ReadOrWrite,
+			Format:         test.format,
+			MinFilter:      gl.NEAREST,
+			MagFilter:      gl.NEAREST,
+			Xtype:          test.xtype,
+			InternalFormat: test.internal,
+		}, nil)
+		if err != nil {
+			t.Errorf("internal format %#x: %s", test.internal, err)
+			continue
+		}
+		fb := glgl.NewFramebuffer()
+		err = fb.AttachTexture(test.attachment, tex, 0)
+		if err != nil {
+			t.Errorf("internal format %#x: attaching texture: %s", test.internal, err)
+		}
+		fb.Delete()
+		tex.Delete()
+	}
+}
+
+func TestTextureConfig(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	cfg := glgl.TextureImgConfig{
+		Type:           glgl.Texture2D,
+		Width:          2,
+		Height:         2,
+		Access:         glgl.ReadOrWrite,
+		Format:         gl.RED,
+		MinFilter:      gl.NEAREST,
+		MagFilter:      gl.NEAREST,
+		Xtype:          gl.FLOAT,
+		InternalFormat: gl.R32F,
+	}
+	data := []float32{1, 2, 3, 4}
+	tex, err := glgl.NewTextureFromImage(cfg, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tex.Delete()
+	if tex.Config() != cfg {
+		t.Errorf("want stored config %+v, got %+v", cfg, tex.Config())
+	}
+	// Zero config reads back using stored values.
+	got := make([]float32, len(data))
+	err = glgl.GetImage(got, tex, glgl.TextureImgConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range data {
+		if got[i] != data[i] {
+			t.Errorf("pixel %d: want %v, got %v", i, data[i], got[i])
+		}
+	}
+	bad := cfg
+	bad.Xtype = gl.UNSIGNED_BYTE
+	if err = glgl.GetImage(got, tex, bad); err == nil {
+		t.Error("expected error reading back with mismatched xtype")
+	}
+	bad = cfg
+	bad.Width = 4
+	if err = glgl.SetImage2D(tex, bad, make([]float32, 8)); err == nil {
+		t.Error("expected error setting image with mismatched width")
+	}
+	err = glgl.SetImage2D(tex, glgl.TextureImgConfig{}, []float32{5, 6, 7, 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = glgl.GetImage(got, tex, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0] != 5 || got[3] != 8 {
+		t.Errorf("want updated image [5 6 7 8], got %v", got)
+	}
+	err = tex.Resize(3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := tex.Config(); c.Width != 3 || c.Height != 1 {
+		t.Errorf("want resized config 3x1, got %dx%d", c.Width, c.Height)
+	}
+}
+
+func TestTextureUnalignedRows(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	// RGB byte rows of 3 pixels are 9 bytes long, not a multiple of the GL's default alignment of 4.
+	cfg := glgl.TextureImgConfig{
+		Type:           glgl.Texture2D,
+		Width:          3,
+		Height:         2,
+		Format:         gl.RGB,
+		Xtype:          gl.UNSIGNED_BYTE,
+		InternalFormat: gl.RGB8,
+	}
+	data := []byte{
+		1, 2, 3, 4, 5, 6, 7, 8, 9,
+		10, 11, 12, 13, 14, 15, 16, 17, 18,
+	}
+	tex, err := glgl.NewTextureFromImage(cfg, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tex.Delete()
+	got := make([]byte, len(data))
+	if err = glgl.GetImage(got, tex, glgl.TextureImgConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, data) {
+		t.Errorf("want %v, got %v", data, got)
+	}
+	// Bytes past the image must not be written to.
+	big := make([]byte, len(data)+4)
+	if err = glgl.GetImageInto(big, tex, glgl.TextureImgConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(big[:len(data)], data) || !slices.Equal(big[len(data):], make([]byte, 4)) {
+		t.Errorf("want %v followed by zeros, got %v", data, big)
+	}
+	if err = glgl.GetImageInto(got[:len(data)-1], tex, glgl.TextureImgConfig{}); err == nil {
+		t.Error("expected error reading image into too small buffer")
+	}
+}
+
+func TestProgramValidateImageFormats(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	ss, err := glgl.ParseCombined(strings.NewReader(`#shader compute
+#version 430
+layout(local_size_x = 1, local_size_y = 1, local_size_z = 1) in;
+layout(r32f, binding = 1) uniform image2D out_tex;
+void main() {
+	imageStore(out_tex, ivec2(gl_GlobalInvocationID.xy), vec4(1.0));
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := glgl.CompileProgram(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer prog.Delete()
+	prog.Bind()
+	cfg := glgl.TextureImgConfig{
+		Type:           glgl.Texture2D,
+		Width:          1,
+		Height:         1,
+		Access:         glgl.WriteOnly,
+		Format:         gl.RGBA,
+		MinFilter:      gl.NEAREST,
+		MagFilter:      gl.NEAREST,
+		Xtype:          gl.FLOAT,
+		InternalFormat: gl.RGBA32F,
+		ImageUnit:      1,
+	}
+	tex, err := glgl.NewTextureFromImage[float32](cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tex.Delete()
+	if err = prog.ValidateImageFormats(ss); err == nil {
+		t.Error("expected error validating rgba32f texture bound to r32f image uniform")
+	}
+	cfg.Format, cfg.InternalFormat = gl.RED, gl.R32F
+	tex2, err := glgl.NewTextureFromImage[float32](cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tex2.Delete()
+	if err = prog.ValidateImageFormats(ss); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,210 @@
+//go:build !tinygo && cgo
+
+package glgl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/soypat/glgl/v4.6-core/glgl"
+)
+
+func TestVertexArraySeparateBuffers(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	const src = `#shader vertex
+#version 330 core
+in vec3 position;
+in vec4 color;
+out vec4 vcolor;
+void main() {
+	vcolor = color;
+	gl_Position = vec4(position, 1.0);
+}
+#shader fragment
+#version 330 core
+in vec4 vcolor;
+out vec4 outputColor;
+void main() {
+	outputColor = vcolor;
+}
+`
+	ss, err := glgl.ParseCombined(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := glgl.CompileProgram(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer prog.Delete()
+	positions, err := glgl.NewVertexBuffer(glgl.StaticDraw, []float32{-0.5, -0.5, 0, 0, 0.5, 0, 0.5, -0.5, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer positions.Delete()
+	colors, err := glgl.NewVertexBuffer(glgl.StaticDraw, []float32{1, 0, 0, 1, 0, 1, 0, 1, 0, 0, 1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer colors.Delete()
+	vao := glgl.NewVAO()
+	err = vao.AddAttributeFromBuffer(positions, glgl.AttribLayout{Program: prog, Type: glgl.Float32, Name: "position\x00", Packing: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = vao.AddAttributeFromBuffer(colors, glgl.AttribLayout{Program: prog, Type: glgl.Float32, Name: "color\x00", Packing: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name string
+		vbo  glgl.VertexBuffer
+	}{
+		{name: "position\x00", vbo: positions},
+		{name: "color\x00", vbo: colors},
+	} {
+		loc := gl.GetAttribLocation(prog.ID(), gl.Str(test.name))
+		var bound int32
+		gl.GetVertexAttribiv(uint32(loc), gl.VERTEX_ATTRIB_ARRAY_BUFFER_BINDING, &bound)
+		if uint32(bound) != test.vbo.ID() {
+			t.Errorf("attribute %q reads from buffer %d, want %d", test.name[:len(test.name)-1], bound, test.vbo.ID())
+		}
+	}
+	layouts := vao.Layouts()
+	if len(layouts) != 2 || layouts[0].Name != "position\x00" || layouts[1].Name != "color\x00" {
+		t.Fatalf("unexpected recorded layouts %+v", layouts)
+	}
+	if layouts[0].Stride != 3*4 || layouts[1].Stride != 4*4 {
+		t.Errorf("want computed strides 12 and 16, got %d and %d", layouts[0].Stride, layouts[1].Stride)
+	}
+	// Rebind must restore the attribute state after it is disabled.
+	loc := uint32(gl.GetAttribLocation(prog.ID(), gl.Str("color\x00")))
+	gl.DisableVertexAttribArray(loc)
+	err = vao.Rebind()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var enabled int32
+	gl.GetVertexAttribiv(loc, gl.VERTEX_ATTRIB_ARRAY_ENABLED, &enabled)
+	if enabled == 0 {
+		t.Error("attribute not enabled after Rebind")
+	}
+}
+
+func TestProgramAttributes(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	const src = `#shader vertex
+#version 330 core
+layout(location = 2) in vec4 color;
+layout(location = 0) in vec3 position;
+layout(location = 1) in ivec2 id;
+out vec4 vcolor;
+flat out ivec2 vid;
+void main() {
+	vcolor = color;
+	vid = id + gl_VertexID;
+	gl_Position = vec4(position, 1.0);
+}
+#shader fragment
+#version 330 core
+in vec4 vcolor;
+flat in ivec2 vid;
+out vec4 outputColor;
+void main() {
+	outputColor = vcolor + float(vid.x+vid.y);
+}
+`
+	ss, err := glgl.ParseCombined(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := glgl.CompileProgram(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer prog.Delete()
+	attrs, err := prog.ActiveAttributes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Attributes are sorted by location and built-ins like gl_VertexID are excluded.
+	want := []glgl.ActiveAttribute{
+		{Name: "position", Location: 0, GLSLType: gl.FLOAT_VEC3, Size: 1},
+		{Name: "id", Location: 1, GLSLType: gl.INT_VEC2, Size: 1},
+		{Name: "color", Location: 2, GLSLType: gl.FLOAT_VEC4, Size: 1},
+	}
+	if len(attrs) != len(want) {
+		t.Fatalf("want %d active attributes, got %+v", len(want), attrs)
+	}
+	for i := range want {
+		if attrs[i] != want[i] {
+			t.Errorf("attribute %d: want %+v, got %+v", i, want[i], attrs[i])
+		}
+	}
+
+	// One interleaved vertex: position(3 floats), id(2 ints), color(4 floats).
+	vbo, err := glgl.NewVertexBuffer(glgl.StaticDraw, []float32{0, 0, 0, 0, 0, 1, 1, 1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer vbo.Delete()
+	vao := glgl.NewVAO()
+	err = vao.BindProgramAttributes(vbo, prog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const stride = 3*4 + 2*4 + 4*4
+	wantOffsets := []int{0, 3 * 4, 3*4 + 2*4}
+	layouts := vao.Layouts()
+	if len(layouts) != len(want) {
+		t.Fatalf("want %d recorded layouts, got %+v", len(want), layouts)
+	}
+	for i, layout := range layouts {
+		if layout.Stride != stride || layout.Offset != wantOffsets[i] {
+			t.Errorf("attribute %q: want stride %d and offset %d, got %d and %d", want[i].Name, stride, wantOffsets[i], layout.Stride, layout.Offset)
+		}
+		var glStride, bound, integer int32
+		gl.GetVertexAttribiv(want[i].Location, gl.VERTEX_ATTRIB_ARRAY_STRIDE, &glStride)
+		gl.GetVertexAttribiv(want[i].Location, gl.VERTEX_ATTRIB_ARRAY_BUFFER_BINDING, &bound)
+		gl.GetVertexAttribiv(want[i].Location, gl.VERTEX_ATTRIB_ARRAY_INTEGER, &integer)
+		if glStride != stride || uint32(bound) != vbo.ID() {
+			t.Errorf("attribute %q: want GL stride %d reading buffer %d, got %d reading %d", want[i].Name, stride, vbo.ID(), glStride, bound)
+		}
+		if wantInteger := want[i].GLSLType == gl.INT_VEC2; (integer != 0) != wantInteger {
+			t.Errorf("attribute %q: want integer attribute %v", want[i].Name, wantInteger)
+		}
+	}
+}
+
+func TestPushState(t *testing.T) {
+	term := initTestWindow(t)
+	defer term()
+	vao := glgl.NewVAO()
+	vao.Bind()
+	gl.ActiveTexture(gl.TEXTURE3)
+	var want, got int32
+	gl.GetIntegerv(gl.VERTEX_ARRAY_BINDING, &want)
+	restoreVAO := glgl.PushVertexArray()
+	restoreTex := glgl.PushActiveTexture()
+	restoreProg := glgl.PushProgram()
+	other := glgl.NewVAO()
+	other.Bind()
+	gl.ActiveTexture(gl.TEXTURE0)
+	restoreProg()
+	restoreTex()
+	restoreVAO()
+	gl.GetIntegerv(gl.VERTEX_ARRAY_BINDING, &got)
+	if want == 0 || got != want {
+		t.Errorf("want restored vertex array %d, got %d", want, got)
+	}
+	gl.GetIntegerv(gl.ACTIVE_TEXTURE, &got)
+	if got != gl.TEXTURE3 {
+		t.Errorf("want restored active texture %d, got %d", gl.TEXTURE3, got)
+	}
+	if err := glgl.Err(); err != nil {
+		t.Error(err)
+	}
+}